@@ -7,6 +7,7 @@ import (
 
 	"github.com/urfave/cli/v3"
 
+	"github.com/winder/algorand-navigator/messages"
 	"github.com/winder/algorand-navigator/tui"
 	"github.com/winder/algorand-navigator/tui/args"
 	"github.com/winder/algorand-navigator/version"
@@ -81,6 +82,27 @@ func makeCommand() *cli.Command {
 				Sources:     cli.EnvVars("ALGORAND_BIN"),
 				Destination: &args.AlgodBinDir,
 			},
+			&cli.StringFlag{
+				Name:        "kmd-url",
+				Usage:       "kmd URL and port used to sign transactions, by default the kmd in the data directory is used.",
+				Value:       "",
+				Sources:     cli.EnvVars("KMD_URL"),
+				Destination: &args.KmdURL,
+			},
+			&cli.StringFlag{
+				Name:        "kmd-token",
+				Usage:       "kmd REST API token.",
+				Value:       "",
+				Sources:     cli.EnvVars("KMD_TOKEN"),
+				Destination: &args.KmdToken,
+			},
+			&cli.StringFlag{
+				Name:        "catchpoint-url",
+				Usage:       "URL of the latest catchpoint used by fast catchup, <network> is replaced with the network of the node.",
+				Value:       messages.DefaultCatchpointSource,
+				Sources:     cli.EnvVars("CATCHPOINT_URL"),
+				Destination: &args.CatchpointURL,
+			},
 			&cli.StringSliceFlag{
 				Name:        "watch-list",
 				Aliases:     []string{"w"},
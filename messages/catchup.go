@@ -0,0 +1,270 @@
+// Copyright (C) 2019-2022 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package messages
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// DefaultCatchpointSource is where the latest catchpoint of each network is
+// published. <network> is replaced with the network of the node.
+const DefaultCatchpointSource = "https://algorand-catchpoints.s3.us-east-2.amazonaws.com/channel/<network>/latest.catchpoint"
+
+// networkPlaceholder is replaced in the catchpoint source.
+const networkPlaceholder = "<network>"
+
+// catchupTimeout limits the catchpoint source and algod catchup requests.
+const catchupTimeout = 30 * time.Second
+
+// catchpointLabel matches labels like 30000000#HASH.
+var catchpointLabel = regexp.MustCompile(`^([0-9]+)#([A-Z2-7]{52})$`)
+
+// NetworkFromGenesisID returns the network name of a genesis ID, for example
+// testnet for testnet-v1.0.
+func NetworkFromGenesisID(genesisID string) string {
+	return strings.Split(genesisID, "-")[0]
+}
+
+// SetCatchpointSource configures where the latest catchpoint is downloaded
+// from. When it isn't set DefaultCatchpointSource is used.
+func (r *Requestor) SetCatchpointSource(source string) {
+	r.catchpointSource = source
+}
+
+// CatchpointSource returns the catchpoint source URL, it may contain the
+// <network> placeholder.
+func (r Requestor) CatchpointSource() string {
+	if r.catchpointSource == "" {
+		return DefaultCatchpointSource
+	}
+	return r.catchpointSource
+}
+
+// Catchpoint is a catchpoint label, and the network it belongs to when that
+// is known.
+type Catchpoint struct {
+	Label   string
+	Round   uint64
+	Network string
+}
+
+// ParseCatchpoint parses a catchpoint label. The label may be preceded by the
+// genesis ID or network name, separated with whitespace, which is used to
+// check the network before starting a catchup. Empty lines and lines starting
+// with // are ignored, so that the text can be read from a file.
+func ParseCatchpoint(text string) (Catchpoint, error) {
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "//") {
+			continue
+		}
+
+		var c Catchpoint
+		fields := strings.Fields(line)
+		switch len(fields) {
+		case 1:
+			c.Label = fields[0]
+		case 2:
+			c.Network = NetworkFromGenesisID(fields[0])
+			c.Label = fields[1]
+		default:
+			return Catchpoint{}, fmt.Errorf("expected a catchpoint label like 30000000#HASH, not '%s'", line)
+		}
+
+		match := catchpointLabel.FindStringSubmatch(c.Label)
+		if match == nil {
+			return Catchpoint{}, fmt.Errorf("'%s' is not a catchpoint label, they look like 30000000#HASH", c.Label)
+		}
+		round, err := strconv.ParseUint(match[1], 10, 64)
+		if err != nil {
+			return Catchpoint{}, fmt.Errorf("'%s' has an invalid round: %w", c.Label, err)
+		}
+		c.Round = round
+		return c, nil
+	}
+	return Catchpoint{}, fmt.Errorf("no catchpoint label found")
+}
+
+// CheckNetwork returns an error when the catchpoint is for a different
+// network than the node.
+func (c Catchpoint) CheckNetwork(genesisID string) error {
+	if genesisID == "" {
+		return fmt.Errorf("the node's network is not known yet")
+	}
+	if c.Network != "" && c.Network != NetworkFromGenesisID(genesisID) {
+		return fmt.Errorf("the catchpoint is for %s but the node is on %s", c.Network, genesisID)
+	}
+	return nil
+}
+
+// CatchpointMsg is a catchpoint picked for a fast catchup.
+type CatchpointMsg struct {
+	Catchpoint Catchpoint
+	// Source describes where the catchpoint was found.
+	Source string
+	Err    error
+}
+
+// GetLatestCatchpointCmd provides a tea.Cmd for downloading the latest
+// catchpoint of the node's network from the catchpoint source.
+func (r Requestor) GetLatestCatchpointCmd(genesisID string) tea.Cmd {
+	return func() tea.Msg {
+		network := NetworkFromGenesisID(genesisID)
+		if network == "" {
+			return CatchpointMsg{Err: fmt.Errorf("the node's network is not known yet")}
+		}
+		source := strings.ReplaceAll(r.CatchpointSource(), networkPlaceholder, network)
+		result := CatchpointMsg{Source: source}
+
+		ctx, cancel := context.WithTimeout(context.Background(), catchupTimeout)
+		defer cancel()
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, source, nil)
+		if err != nil {
+			result.Err = err
+			return result
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			result.Err = err
+			return result
+		}
+		defer resp.Body.Close()
+		body, err := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		if err != nil {
+			result.Err = err
+			return result
+		}
+		if resp.StatusCode != http.StatusOK {
+			result.Err = fmt.Errorf("no catchpoint for %s, HTTP %d from %s", network, resp.StatusCode, source)
+			return result
+		}
+
+		result.Catchpoint, result.Err = ParseCatchpoint(string(body))
+		// the source is per network when it has the placeholder.
+		if result.Err == nil && result.Catchpoint.Network == "" && strings.Contains(r.CatchpointSource(), networkPlaceholder) {
+			result.Catchpoint.Network = network
+		}
+		return result
+	}
+}
+
+// ReadCatchpointFileCmd provides a tea.Cmd for reading a catchpoint label
+// from a local file.
+func ReadCatchpointFileCmd(file string) tea.Cmd {
+	return func() tea.Msg {
+		result := CatchpointMsg{Source: file}
+		data, err := os.ReadFile(file)
+		if err != nil {
+			result.Err = err
+			return result
+		}
+		result.Catchpoint, result.Err = ParseCatchpoint(string(data))
+		return result
+	}
+}
+
+// CatchupAction is the fast catchup request which was made.
+type CatchupAction string
+
+// Fast catchup requests.
+const (
+	CatchupStart CatchupAction = "start"
+	CatchupAbort CatchupAction = "abort"
+)
+
+// FastCatchupMsg is the result of starting or aborting a fast catchup.
+type FastCatchupMsg struct {
+	Action     CatchupAction
+	Catchpoint string
+	Err        error
+}
+
+// StartFastCatchupCmd starts a fast catchup to a catchpoint, after checking
+// that the catchpoint is for the node's network.
+func (r Requestor) StartFastCatchupCmd(catchpoint Catchpoint, genesisID string) tea.Cmd {
+	return func() tea.Msg {
+		result := FastCatchupMsg{Action: CatchupStart, Catchpoint: catchpoint.Label}
+		if result.Err = catchpoint.CheckNetwork(genesisID); result.Err != nil {
+			return result
+		}
+		result.Err = r.doFastCatchupRequest(http.MethodPost, catchpoint.Label)
+		return result
+	}
+}
+
+// AbortFastCatchupCmd aborts the fast catchup to a catchpoint.
+func (r Requestor) AbortFastCatchupCmd(catchpoint string) tea.Cmd {
+	return func() tea.Msg {
+		result := FastCatchupMsg{Action: CatchupAbort, Catchpoint: catchpoint}
+		if catchpoint == "" {
+			result.Err = fmt.Errorf("there is no fast catchup to abort")
+			return result
+		}
+		result.Err = r.doFastCatchupRequest(http.MethodDelete, catchpoint)
+		return result
+	}
+}
+
+// doFastCatchupRequest calls the algod catchup endpoint, which requires the
+// admin token.
+func (r Requestor) doFastCatchupRequest(verb, catchpoint string) error {
+	adminToken := strings.TrimSpace(r.adminToken)
+	if adminToken == "" {
+		return fmt.Errorf("cannot use fast catchup without an admin token")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), catchupTimeout)
+	defer cancel()
+	endpoint := fmt.Sprintf("%s/v2/catchup/%s", strings.TrimSuffix(r.url, "/"), url.PathEscape(catchpoint))
+	req, err := http.NewRequestWithContext(ctx, verb, endpoint, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("X-Algo-Api-Token", adminToken)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+
+	// algod errors look like {"message": "..."}
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	var algodErr struct {
+		Message string `json:"message"`
+	}
+	if json.Unmarshal(body, &algodErr) == nil && algodErr.Message != "" {
+		return fmt.Errorf("algod returned HTTP %d: %s", resp.StatusCode, algodErr.Message)
+	}
+	return fmt.Errorf("algod returned HTTP %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+}
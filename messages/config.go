@@ -0,0 +1,120 @@
+// Copyright (C) 2019-2022 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package messages
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// configFile is the node configuration file in the data directory.
+const configFile = "config.json"
+
+// ConfigFileMsg has the content of the node's config.json. Data is empty when
+// the file doesn't exist, in which case algod uses the defaults.
+type ConfigFileMsg struct {
+	Path string
+	Data []byte
+	Err  error
+}
+
+// GetConfigFileCmd provides a tea.Cmd for fetching a ConfigFileMsg.
+func (r Requestor) GetConfigFileCmd() tea.Cmd {
+	return func() tea.Msg {
+		if r.dataDir == "" {
+			return ConfigFileMsg{Err: fmt.Errorf("data directory not set")}
+		}
+		file := path.Join(r.dataDir, configFile)
+		data, err := os.ReadFile(file)
+		if errors.Is(err, os.ErrNotExist) {
+			err = nil
+		}
+		return ConfigFileMsg{
+			Path: file,
+			Data: data,
+			Err:  err,
+		}
+	}
+}
+
+// ConfigSavedMsg is the result of saving config.json. Backup is empty when
+// there was no file to back up.
+type ConfigSavedMsg struct {
+	Path   string
+	Backup string
+	Err    error
+}
+
+// SaveConfigCmd replaces the node's config.json, the previous file is kept
+// next to it with a timestamp.
+func (r Requestor) SaveConfigCmd(data []byte) tea.Cmd {
+	return func() tea.Msg {
+		if r.dataDir == "" {
+			return ConfigSavedMsg{Err: fmt.Errorf("data directory not set")}
+		}
+		result := ConfigSavedMsg{Path: path.Join(r.dataDir, configFile)}
+
+		previous, err := os.ReadFile(result.Path)
+		switch {
+		case err == nil:
+			result.Backup = fmt.Sprintf("%s.%s.bak", result.Path, time.Now().Format("20060102-150405"))
+			if err := os.WriteFile(result.Backup, previous, 0644); err != nil {
+				result.Err = fmt.Errorf("unable to write backup: %w", err)
+				return result
+			}
+		case !errors.Is(err, os.ErrNotExist):
+			result.Err = err
+			return result
+		}
+
+		// write a temporary file first so that a failed write doesn't leave a
+		// partial config behind.
+		tmp := result.Path + ".tmp"
+		if err := os.WriteFile(tmp, data, 0644); err != nil {
+			result.Err = err
+			return result
+		}
+		result.Err = os.Rename(tmp, result.Path)
+		return result
+	}
+}
+
+// NodeRestartMsg is the result of restarting the node.
+type NodeRestartMsg struct {
+	Output string
+	Err    error
+}
+
+// RestartNodeCmd restarts the node with 'goal node restart', algod only reads
+// config.json at start up.
+func (r Requestor) RestartNodeCmd() tea.Cmd {
+	return func() tea.Msg {
+		if !r.CanUseGoal() {
+			return NodeRestartMsg{Err: fmt.Errorf("the node can only be restarted when the bin directory is set")}
+		}
+		output, err := r.goal("node", "restart")
+		return NodeRestartMsg{
+			Output: output,
+			Err:    err,
+		}
+	}
+}
@@ -0,0 +1,124 @@
+// Copyright (C) 2019-2022 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package messages
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/algorand/go-algorand-sdk/v2/client/kmd"
+	"github.com/algorand/go-algorand-sdk/v2/types"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// kmdDir is where kmd keeps its files in the data directory.
+const kmdDir = "kmd-v0.5"
+
+// SetKmd configures the kmd REST API. When it isn't set the kmd started from
+// the data directory is used.
+func (r *Requestor) SetKmd(url, token string) {
+	r.kmdURL = url
+	r.kmdToken = token
+}
+
+func (r Requestor) kmdClient() (kmd.Client, error) {
+	url, token := r.kmdURL, r.kmdToken
+	if url == "" {
+		if r.dataDir == "" {
+			return kmd.Client{}, fmt.Errorf("kmd is not configured")
+		}
+		netBytes, err := os.ReadFile(path.Join(r.dataDir, kmdDir, "kmd.net"))
+		if err != nil {
+			return kmd.Client{}, fmt.Errorf("kmd is not running, start it with 'goal kmd start'")
+		}
+		tokenBytes, err := os.ReadFile(path.Join(r.dataDir, kmdDir, "kmd.token"))
+		if err != nil {
+			return kmd.Client{}, fmt.Errorf("unable to read the kmd token: %w", err)
+		}
+		url = strings.TrimSpace(string(netBytes))
+		token = strings.TrimSpace(string(tokenBytes))
+	}
+	if !strings.HasPrefix(url, "http") {
+		url = "http://" + url
+	}
+	return kmd.MakeClient(strings.TrimSuffix(url, "/"), token)
+}
+
+// CanUseKmd reports whether kmd is available for signing transactions.
+func (r Requestor) CanUseKmd() bool {
+	_, err := r.kmdClient()
+	return err == nil
+}
+
+// SignAndSendMsg is the result of signing a transaction with kmd and sending
+// it to the network.
+type SignAndSendMsg struct {
+	TxID string
+	Err  error
+}
+
+// signWithKmd signs a transaction with the key of the sender in a kmd wallet.
+func (r Requestor) signWithKmd(tx types.Transaction, walletName, password string) ([]byte, error) {
+	client, err := r.kmdClient()
+	if err != nil {
+		return nil, err
+	}
+	wallets, err := client.ListWallets()
+	if err != nil {
+		return nil, fmt.Errorf("unable to list wallets: %w", err)
+	}
+	var walletID string
+	for _, w := range wallets.Wallets {
+		if w.Name == walletName {
+			walletID = w.ID
+		}
+	}
+	if walletID == "" {
+		return nil, fmt.Errorf("wallet '%s' not found", walletName)
+	}
+
+	handle, err := client.InitWalletHandle(walletID, password)
+	if err != nil {
+		return nil, fmt.Errorf("unable to unlock wallet: %w", err)
+	}
+	defer client.ReleaseWalletHandle(handle.WalletHandleToken)
+
+	signed, err := client.SignTransaction(handle.WalletHandleToken, password, tx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to sign transaction: %w", err)
+	}
+	return signed.SignedTransaction, nil
+}
+
+// SignAndSendCmd provides a tea.Cmd which signs a transaction using a kmd
+// wallet and sends it to the network.
+func (r Requestor) SignAndSendCmd(tx types.Transaction, walletName, password string) tea.Cmd {
+	return func() tea.Msg {
+		stxn, err := r.signWithKmd(tx, walletName, password)
+		if err != nil {
+			return SignAndSendMsg{Err: err}
+		}
+		txid, err := r.Client.SendRawTransaction(stxn).Do(context.Background())
+		return SignAndSendMsg{
+			TxID: txid,
+			Err:  err,
+		}
+	}
+}
@@ -0,0 +1,184 @@
+// Copyright (C) 2019-2022 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package messages
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/algorand/go-algorand-sdk/v2/client/v2/common/models"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ParticipationKey is a participation key installed on the node.
+type ParticipationKey struct {
+	ID                  string                      `json:"id"`
+	Address             string                      `json:"address"`
+	EffectiveFirstValid uint64                      `json:"effective-first-valid,omitempty"`
+	EffectiveLastValid  uint64                      `json:"effective-last-valid,omitempty"`
+	LastVote            uint64                      `json:"last-vote,omitempty"`
+	LastBlockProposal   uint64                      `json:"last-block-proposal,omitempty"`
+	LastStateProof      uint64                      `json:"last-state-proof,omitempty"`
+	Key                 models.AccountParticipation `json:"key"`
+}
+
+// adminRequest calls an algod endpoint which requires the admin token.
+func (r Requestor) adminRequest(method, endpoint string, query url.Values) ([]byte, error) {
+	if r.adminToken == "" {
+		return nil, fmt.Errorf("an admin token is required")
+	}
+	u := strings.TrimSuffix(r.url, "/") + endpoint
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	req, err := http.NewRequest(method, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("X-Algo-Api-Token", r.adminToken)
+
+	client := &http.Client{Timeout: 30 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		var apiErr struct {
+			Message string `json:"message"`
+		}
+		if json.Unmarshal(body, &apiErr) == nil && apiErr.Message != "" {
+			return nil, fmt.Errorf("HTTP %d: %s", resp.StatusCode, apiErr.Message)
+		}
+		return nil, fmt.Errorf("HTTP %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return body, nil
+}
+
+// ParticipationKeysMsg has the participation keys installed on the node.
+type ParticipationKeysMsg struct {
+	Keys []ParticipationKey
+	Err  error
+}
+
+// GetParticipationKeysCmd provides a tea.Cmd for fetching a ParticipationKeysMsg.
+func (r Requestor) GetParticipationKeysCmd() tea.Cmd {
+	return func() tea.Msg {
+		body, err := r.adminRequest(http.MethodGet, "/v2/participation", nil)
+		if err != nil {
+			return ParticipationKeysMsg{Err: err}
+		}
+		var keys []ParticipationKey
+		if err := json.Unmarshal(body, &keys); err != nil {
+			return ParticipationKeysMsg{Err: fmt.Errorf("unable to parse participation keys: %w", err)}
+		}
+		return ParticipationKeysMsg{Keys: keys}
+	}
+}
+
+// ParticipationResultMsg is the result of generating or deleting a
+// participation key.
+type ParticipationResultMsg struct {
+	// Action describes what was requested, for example "generate".
+	Action string
+	Output string
+	Err    error
+}
+
+var errNoKeyManagement = fmt.Errorf("an admin token, or the bin and data directories, are required")
+
+// CanUseGoal reports whether goal commands can be run against the node.
+func (r Requestor) CanUseGoal() bool {
+	return r.binDir != "" && r.dataDir != ""
+}
+
+// goal runs a goal command against the node's data directory.
+func (r Requestor) goal(args ...string) (string, error) {
+	args = append(args, "-d", r.dataDir)
+	c := exec.Command(fmt.Sprintf("%s/goal", r.binDir), args...)
+	output, err := c.CombinedOutput()
+	return strings.TrimSpace(string(output)), err
+}
+
+// GenerateParticipationKeyCmd generates a participation key on the node. The
+// algod endpoint is used when there is an admin token, otherwise goal is used.
+// Key generation takes a while, algod installs the key in the background.
+func (r Requestor) GenerateParticipationKeyCmd(address string, first, last, dilution uint64) tea.Cmd {
+	return func() tea.Msg {
+		result := ParticipationResultMsg{Action: "generate"}
+		if r.adminToken == "" && !r.CanUseGoal() {
+			result.Err = errNoKeyManagement
+			return result
+		}
+		if r.adminToken != "" {
+			query := url.Values{}
+			query.Set("first", strconv.FormatUint(first, 10))
+			query.Set("last", strconv.FormatUint(last, 10))
+			if dilution != 0 {
+				query.Set("dilution", strconv.FormatUint(dilution, 10))
+			}
+			_, result.Err = r.adminRequest(http.MethodPost, "/v2/participation/generate/"+url.PathEscape(address), query)
+			if result.Err == nil {
+				result.Output = fmt.Sprintf("Generating a participation key for %s, it is installed when ready.", address)
+			}
+			return result
+		}
+
+		args := []string{"account", "addpartkey",
+			"--address", address,
+			"--roundFirstValid", strconv.FormatUint(first, 10),
+			"--roundLastValid", strconv.FormatUint(last, 10)}
+		if dilution != 0 {
+			args = append(args, "--keyDilution", strconv.FormatUint(dilution, 10))
+		}
+		result.Output, result.Err = r.goal(args...)
+		return result
+	}
+}
+
+// DeleteParticipationKeyCmd deletes a participation key from the node.
+func (r Requestor) DeleteParticipationKeyCmd(id string) tea.Cmd {
+	return func() tea.Msg {
+		result := ParticipationResultMsg{Action: "delete"}
+		if r.adminToken == "" && !r.CanUseGoal() {
+			result.Err = errNoKeyManagement
+			return result
+		}
+		if r.adminToken != "" {
+			_, result.Err = r.adminRequest(http.MethodDelete, "/v2/participation/"+url.PathEscape(id), nil)
+			if result.Err == nil {
+				result.Output = fmt.Sprintf("Deleted participation key %s.", id)
+			}
+			return result
+		}
+
+		result.Output, result.Err = r.goal("account", "deletepartkey", "--partkeyid", id)
+		return result
+	}
+}
@@ -0,0 +1,309 @@
+// Copyright (C) 2019-2022 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package messages
+
+import (
+	"bufio"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Peer is a network connection of the node, or a phonebook entry.
+type Peer struct {
+	// Address is the remote "ip:port", or "host:port" for phonebook entries.
+	Address  string
+	Incoming bool
+}
+
+// PeersMsg has the connections of the node. algod does not publish its
+// connections, so they are read from the operating system using the pid in
+// the data directory. When that isn't possible the phonebook is used instead.
+type PeersMsg struct {
+	Peers []Peer
+
+	// Phonebook is set when Peers are phonebook entries, FallbackReason
+	// explains why the connections are not available.
+	Phonebook      bool
+	FallbackReason error
+
+	Err error
+}
+
+// GetPeersCmd provides a tea.Cmd for fetching a PeersMsg.
+func (r Requestor) GetPeersCmd() tea.Cmd {
+	return func() tea.Msg {
+		if r.dataDir == "" {
+			return PeersMsg{Err: fmt.Errorf("data directory not set")}
+		}
+
+		peers, err := nodeConnections(r.dataDir, apiPort(r.url))
+		if err == nil {
+			return PeersMsg{Peers: peers}
+		}
+
+		book, pbErr := readPhonebook(r.dataDir)
+		if pbErr != nil {
+			return PeersMsg{Err: fmt.Errorf("%s, %s", err, pbErr)}
+		}
+		return PeersMsg{
+			Peers:          book,
+			Phonebook:      true,
+			FallbackReason: err,
+		}
+	}
+}
+
+func apiPort(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	return u.Port()
+}
+
+// readPhonebook reads the peers which are added to the DNS bootstrap peers.
+func readPhonebook(dataDir string) ([]Peer, error) {
+	data, err := os.ReadFile(path.Join(dataDir, "phonebook.json"))
+	if err != nil {
+		return nil, fmt.Errorf("phonebook.json not found")
+	}
+	var phonebook struct {
+		Include []string
+	}
+	if err := json.Unmarshal(data, &phonebook); err != nil {
+		return nil, fmt.Errorf("unable to parse phonebook.json: %w", err)
+	}
+	peers := make([]Peer, 0, len(phonebook.Include))
+	for _, addr := range phonebook.Include {
+		peers = append(peers, Peer{Address: addr})
+	}
+	return peers, nil
+}
+
+// socket is an entry of /proc/<pid>/net/tcp.
+type socket struct {
+	local  string
+	remote string
+	state  string
+	inode  string
+}
+
+const (
+	tcpEstablished = "01"
+	tcpListen      = "0A"
+)
+
+// nodeConnections lists the established TCP connections of algod. Connections
+// to the REST API are not peers and are skipped.
+func nodeConnections(dataDir, restPort string) ([]Peer, error) {
+	pidBytes, err := os.ReadFile(path.Join(dataDir, "algod.pid"))
+	if err != nil {
+		return nil, fmt.Errorf("algod.pid not found, is the node running")
+	}
+	pid := strings.TrimSpace(string(pidBytes))
+
+	fdDir := path.Join("/proc", pid, "fd")
+	fds, err := os.ReadDir(fdDir)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the node's connections: %w", err)
+	}
+	inodes := make(map[string]bool)
+	for _, fd := range fds {
+		link, err := os.Readlink(path.Join(fdDir, fd.Name()))
+		if err == nil && strings.HasPrefix(link, "socket:[") {
+			inodes[strings.TrimSuffix(strings.TrimPrefix(link, "socket:["), "]")] = true
+		}
+	}
+
+	var sockets []socket
+	for _, file := range []string{"tcp", "tcp6"} {
+		s, err := readSockets(path.Join("/proc", pid, "net", file))
+		if err != nil && file == "tcp" {
+			return nil, err
+		}
+		sockets = append(sockets, s...)
+	}
+
+	listening := make(map[string]bool)
+	for _, s := range sockets {
+		if inodes[s.inode] && s.state == tcpListen {
+			_, port, _ := net.SplitHostPort(s.local)
+			listening[port] = true
+		}
+	}
+
+	var peers []Peer
+	for _, s := range sockets {
+		if !inodes[s.inode] || s.state != tcpEstablished {
+			continue
+		}
+		_, port, _ := net.SplitHostPort(s.local)
+		if port == restPort {
+			continue
+		}
+		peers = append(peers, Peer{
+			Address:  s.remote,
+			Incoming: listening[port],
+		})
+	}
+	return peers, nil
+}
+
+func readSockets(file string) ([]socket, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the node's connections: %w", err)
+	}
+	defer f.Close()
+
+	var sockets []socket
+	scanner := bufio.NewScanner(f)
+	// skip the header.
+	scanner.Scan()
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 10 {
+			continue
+		}
+		local, err := decodeSocketAddress(fields[1])
+		if err != nil {
+			continue
+		}
+		remote, err := decodeSocketAddress(fields[2])
+		if err != nil {
+			continue
+		}
+		sockets = append(sockets, socket{
+			local:  local,
+			remote: remote,
+			state:  fields[3],
+			inode:  fields[9],
+		})
+	}
+	return sockets, scanner.Err()
+}
+
+// decodeSocketAddress converts the hex "address:port" format used by /proc,
+// the address is stored as little endian 32 bit words.
+func decodeSocketAddress(s string) (string, error) {
+	parts := strings.Split(s, ":")
+	if len(parts) != 2 {
+		return "", fmt.Errorf("malformed address '%s'", s)
+	}
+	raw, err := hex.DecodeString(parts[0])
+	if err != nil || len(raw)%4 != 0 {
+		return "", fmt.Errorf("malformed address '%s'", s)
+	}
+	port, err := strconv.ParseUint(parts[1], 16, 16)
+	if err != nil {
+		return "", fmt.Errorf("malformed port '%s'", s)
+	}
+	ip := make(net.IP, len(raw))
+	for i := 0; i < len(raw); i += 4 {
+		ip[i], ip[i+1], ip[i+2], ip[i+3] = raw[i+3], raw[i+2], raw[i+1], raw[i]
+	}
+	if v4 := ip.To4(); v4 != nil {
+		ip = v4
+	}
+	return net.JoinHostPort(ip.String(), strconv.FormatUint(port, 10)), nil
+}
+
+// RelaysMsg has the relays and archivers published in the DNS SRV records of
+// the network. The keys are both "host:port" and "ip:port".
+type RelaysMsg struct {
+	Relays    map[string]bool
+	Archivers map[string]bool
+	Err       error
+}
+
+// dnsBootstrap returns the domain used to discover relays.
+func (r Requestor) dnsBootstrap(network string) string {
+	bootstrap := "<network>.algorand.network"
+	if r.dataDir != "" {
+		var cfg struct {
+			DNSBootstrapID string
+		}
+		data, err := os.ReadFile(path.Join(r.dataDir, "config.json"))
+		if err == nil && json.Unmarshal(data, &cfg) == nil && cfg.DNSBootstrapID != "" {
+			bootstrap = cfg.DNSBootstrapID
+		}
+	}
+	// newer versions add backup domains as query parameters.
+	bootstrap = strings.Split(bootstrap, "?")[0]
+	return strings.ReplaceAll(bootstrap, "<network>", network)
+}
+
+// resolveSRV looks up the SRV records of a service along with the addresses
+// of each target.
+func resolveSRV(service, domain string) (map[string]bool, error) {
+	_, records, err := net.LookupSRV(service, "tcp", domain)
+	if err != nil {
+		return nil, err
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	result := make(map[string]bool)
+	for _, record := range records {
+		host := strings.TrimSuffix(record.Target, ".")
+		port := strconv.Itoa(int(record.Port))
+		result[net.JoinHostPort(host, port)] = true
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			addrs, err := net.LookupHost(host)
+			if err != nil {
+				return
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			for _, addr := range addrs {
+				result[net.JoinHostPort(addr, port)] = true
+			}
+		}()
+	}
+	wg.Wait()
+	return result, nil
+}
+
+// GetRelaysCmd provides a tea.Cmd for fetching a RelaysMsg.
+func (r Requestor) GetRelaysCmd(network string) tea.Cmd {
+	return func() tea.Msg {
+		domain := r.dnsBootstrap(network)
+		relays, err := resolveSRV("algobootstrap", domain)
+		if err != nil {
+			return RelaysMsg{Err: fmt.Errorf("unable to resolve relays for %s: %w", domain, err)}
+		}
+		// not every network has archivers.
+		archivers, _ := resolveSRV("archive", domain)
+		return RelaysMsg{
+			Relays:    relays,
+			Archivers: archivers,
+		}
+	}
+}
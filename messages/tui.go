@@ -23,8 +23,8 @@ import (
 	"net/http"
 	"os"
 	"os/exec"
-	"path"
 	"strings"
+	"time"
 
 	"github.com/algorand/go-algorand-sdk/v2/client/v2/algod"
 	"github.com/algorand/go-algorand-sdk/v2/client/v2/common/models"
@@ -41,6 +41,10 @@ type Requestor struct {
 	token      string
 	dataDir    string
 	binDir     string
+	kmdURL     string
+	kmdToken   string
+
+	catchpointSource string
 }
 
 // MakeRequestor builds the requestor object.
@@ -78,18 +82,6 @@ func formatVersion(ver models.Version) string {
 		ver.Build.CommitHash)
 }
 
-// GetConfigs returns the node config.json file if possible.
-func (r Requestor) GetConfigs() string {
-	if r.dataDir == "" {
-		return "data directory not set"
-	}
-	configs, err := os.ReadFile(path.Join(r.dataDir, "config.json"))
-	if err != nil {
-		return "config.json file not found"
-	}
-	return string(configs)
-}
-
 // GetNetworkCmd provides a tea.Cmd for fetching a NetworkMsg.
 func (r Requestor) GetNetworkCmd() tea.Cmd {
 	return func() tea.Msg {
@@ -134,9 +126,52 @@ func (r Requestor) GetStatusCmd() tea.Cmd {
 	}
 }
 
+// MetricsMsg has the metrics served by algod in the Prometheus text format.
+type MetricsMsg struct {
+	Body string
+	Time time.Time
+	Err  error
+}
+
+// GetMetricsCmd provides a tea.Cmd for fetching a MetricsMsg. The metrics
+// endpoint is only available when EnableMetricReporting is set.
+func (r Requestor) GetMetricsCmd() tea.Cmd {
+	return func() tea.Msg {
+		req, err := http.NewRequest(http.MethodGet, strings.TrimSuffix(r.url, "/")+"/metrics", nil)
+		if err != nil {
+			return MetricsMsg{Err: err}
+		}
+		req.Header.Set("X-Algo-Api-Token", r.token)
+
+		client := &http.Client{Timeout: 5 * time.Second}
+		resp, err := client.Do(req)
+		if err != nil {
+			return MetricsMsg{Err: err}
+		}
+		defer resp.Body.Close()
+
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return MetricsMsg{Err: err}
+		}
+		if resp.StatusCode == http.StatusNotFound {
+			return MetricsMsg{Err: fmt.Errorf("metrics are not available, set EnableMetricReporting in config.json")}
+		}
+		if resp.StatusCode != http.StatusOK {
+			return MetricsMsg{Err: fmt.Errorf("HTTP %d: %s", resp.StatusCode, body)}
+		}
+
+		return MetricsMsg{
+			Body: string(body),
+			Time: time.Now(),
+		}
+	}
+}
+
 // AccountStatusMsg has account balance information.
 type AccountStatusMsg struct {
 	Balances map[types.Address]map[uint64]uint64
+	Accounts map[types.Address]models.Account
 	Err      error
 }
 
@@ -145,6 +180,7 @@ func (r Requestor) GetAccountStatusCmd(accounts []types.Address) tea.Cmd {
 	return func() tea.Msg {
 		var rval AccountStatusMsg
 		rval.Balances = make(map[types.Address]map[uint64]uint64)
+		rval.Accounts = make(map[types.Address]models.Account)
 
 		for _, acct := range accounts {
 			resp, err := r.Client.AccountInformation(acct.String()).Do(context.Background())
@@ -153,6 +189,7 @@ func (r Requestor) GetAccountStatusCmd(accounts []types.Address) tea.Cmd {
 					Err: err,
 				}
 			}
+			rval.Accounts[acct] = resp
 			rval.Balances[acct] = make(map[uint64]uint64)
 
 			// algos at the special index
@@ -168,65 +205,6 @@ func (r Requestor) GetAccountStatusCmd(accounts []types.Address) tea.Cmd {
 	}
 }
 
-func doFastCatchupRequest(rootURL, adminToken, verb, network string) error {
-	if adminToken == "" {
-		return fmt.Errorf("cannot use fast catchup without an admin token")
-	}
-	resp, err := http.Get(fmt.Sprintf("https://algorand-catchpoints.s3.us-east-2.amazonaws.com/channel/%s/latest.catchpoint", network))
-	if err != nil {
-		panic(err)
-	}
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		panic(err)
-	}
-	catchpoint := strings.Replace(string(body), "#", "%23", 1)
-
-	//start fast catchup
-	url := fmt.Sprintf("%s/v2/catchup/%s", rootURL, catchpoint)
-	url = url[:len(url)-1] // remove \n
-	if err != nil {
-		panic(err)
-	}
-	req, err := http.NewRequest(verb, url, nil)
-	if err != nil {
-		panic(err)
-	}
-	req.Header.Set("X-Algo-Api-Token", string(adminToken))
-	req.Header.Set("Content-Type", "application/json")
-
-	client := &http.Client{}
-	resp, err = client.Do(req)
-	if err != nil {
-		return err
-	}
-	defer resp.Body.Close()
-
-	return nil
-}
-
-// StartFastCatchup attempts to start fast catchup for a given network.
-func (r Requestor) StartFastCatchup(network string) tea.Cmd {
-	return func() tea.Msg {
-		err := doFastCatchupRequest(r.url, r.adminToken, http.MethodPost, network)
-		if err != nil {
-			panic(err)
-		}
-		return nil
-	}
-}
-
-// StopFastCatchup attempts to stop fast catchup for a given network.
-func (r Requestor) StopFastCatchup(network string) tea.Cmd {
-	return func() tea.Msg {
-		err := doFastCatchupRequest(r.url, r.adminToken, http.MethodDelete, network)
-		if err != nil {
-			panic(err)
-		}
-		return nil
-	}
-}
-
 func (r Requestor) CanShutdown() bool {
 	return r.binDir != "" && r.dataDir != ""
 }
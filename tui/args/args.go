@@ -8,6 +8,9 @@ type Arguments struct {
 	AlgodAdminToken  string
 	AlgodDataDir     string
 	AlgodBinDir      string
+	KmdURL           string
+	KmdToken         string
+	CatchpointURL    string
 	AddressWatchList []string
 	VersionFlag      bool
 }
@@ -13,9 +13,11 @@ Easy access to **important** tools and node information.
 
 Continuous status is available for:
 * Network information.
-* Protocol upgrade status.
+* Protocol upgrade status, the details are in the Upgrade tab.
 * Catchup sync time.
-* Fast catchup progress.
+* Fast catchup progress, with the rate, a rate sparkline and the remaining
+  time of each phase. Once a fast catchup completes, the time taken by each
+  phase is displayed.
 
 # Explorer
 
@@ -29,6 +31,14 @@ Full real-time access to block information, and aggregations including:
 * Unique assets used in asset transactions.
 * Unique applications used in applications.
 
+Press **g** to jump to a round. Older blocks are loaded automatically when
+scrolling past the bottom of the table.
+
+Press **h** to view the block header: seed, timestamp, previous hash, txn root
+and counter, rewards state, upgrade votes, state proof tracking and the full
+certificate with the proposer, period, step and voters. Press **enter** from the
+header to view the block's transactions.
+
 ## Transactions
 
 Drill into a block for a detailed transaction breakdown:
@@ -36,35 +46,233 @@ Drill into a block for a detailed transaction breakdown:
 * type
 * transfer amount for payment / asset transfer transactions
 * signature type, including inner-transactions
+* transaction ID
+
+Press **o** to change the sorted column and **O** to reverse the order. Press
+**t** to cycle through transaction type filters and **@** to only show
+transactions from one sender. Filters are kept when moving between blocks.
+
+Transactions with inner transactions are marked with **▸**, press **space** to
+expand or collapse them. Inner transactions are indented below their parent
+and can be opened like any other transaction.
+
+Atomic transaction groups are bracketed in the left margin, the first row of a
+group shows the start of the group ID and the size of the group. Press **G** on
+any member for a group summary listing the members and the net balance effect
+on each address, including inner transactions.
+
+## Search
+
+Press **/** to search the loaded blocks by transaction ID, address, asset ID
+or application ID. Prefix a number with **asset:** or **app:** to narrow the
+search. Inner transactions are included in the results.
 
 ## Raw Transaction
 
 View the raw transaction details.
 
+Application calls also include a decoded summary with the on-completion type,
+application ID, foreign references, arguments, logs (including possible
+ARC-28 event selectors) and state changes.
+
+# Mempool
+
+A live view of the node's transaction pool, refreshed every second:
+* pool size
+* fee distribution, including fees pooled by transaction groups
+* the senders with the most pending transactions
+
+Press **w** to watch a transaction ID. The watched transaction moves from
+pending to confirmed, press **enter** once it is confirmed to open its block in
+the explorer. Press **esc** to stop watching.
+
+# Metrics
+
+A dashboard built from algod's Prometheus metrics, with a sparkline of the
+recent history for:
+* peer count
+* transaction pool size
+* ledger round
+* bytes received and sent per second
+
+The sync health of the node is displayed in the footer:
+* **synced**: the node is following the network.
+* **syncing**: the node is catching up block by block.
+* **fast catchup**: the node is catching up from a catchpoint.
+* **slow**: the node has waited more than 15s for a round.
+* **stalled**: the round hasn't changed for more than a minute.
+* **unsupported**: the node stopped at a protocol upgrade it doesn't
+  support, it needs to be updated.
+
+The latest changes of the sync health are listed below the metrics.
+
+The metrics endpoint is only available when **EnableMetricReporting** is set in
+the node's config.json.
+
+# Rounds
+
+Statistics of the last 1000 rounds, built from the blocks loaded by the
+explorer:
+* a histogram of the round times with the p50, p95, max and mean. Block
+  timestamps are in seconds, so are the round times.
+* the accounts which proposed the most blocks, with their share of the blocks.
+  Watched accounts are highlighted.
+* for each watched account, the number of blocks it proposed and the last one.
+
+Proposers are read from the block certificates, they are unknown when the node
+doesn't return them.
+
+# Upgrade
+
+The consensus protocol upgrade vote. The vote parameters of the current
+protocol, the voting window and the number of yes votes required, come from
+the consensus table bundled with the Algorand SDK.
+
+While an upgrade is proposed the page shows:
+* the yes and no votes, the votes still needed and the projected result if
+  the remaining blocks vote like the previous ones.
+* the rounds at which the vote closes and the protocol switches, with the
+  time projected from the recent round times.
+* a chart of the share of yes votes over the voting window, reconstructed
+  from the block headers.
+* the proposers of the last 1000 blocks which voted yes, and those which did
+  not. Watched accounts are highlighted.
+
+A warning is displayed when the node doesn't support the new protocol.
+
+# Peers
+
+The node's incoming and outgoing connections with relay and archival flags and
+the connection age. algod does not publish its connections, so they are read
+from the operating system using the pid in the data directory. Connections which
+existed when the navigator started are marked with **>**, their real age is
+unknown.
+
+Relays and archivers are found in the DNS SRV records of the network, using the
+**DNSBootstrapID** from config.json. When the connections are not available the
+phonebook.json entries from the data directory are shown instead.
+
+# Participation
+
+The participation keys installed on the node, with their valid rounds, vote key
+dilution and the last round each key voted or proposed. A warning is shown when
+every key of an account expires within a week. Listing keys requires the admin
+token.
+
+Press **n** to generate a key, the address defaults to the selected key and the
+first round to the current round. Press **x** to delete the selected key. Both
+ask for confirmation. Keys are generated by algod when an admin token is set,
+otherwise **goal account addpartkey** is used from the bin directory.
+
 # Utilities
 
 Shortcuts for handy utilities.
 
+Press **k** to build a key registration transaction for a watched account.
+Online registrations use a participation key installed on the node, offline
+registrations stop the account from participating. Every field is previewed
+before the transaction is used, from the preview:
+* **w** writes the unsigned transaction to a file for **goal clerk sign**
+* **s** signs it with a kmd wallet and sends it to the network
+
+kmd is found in the data directory, or configured with **--kmd-url** and
+**--kmd-token**.
+
+Press **f** to start a fast catchup, the catchpoint can be:
+* the latest catchpoint of the node's network
+* a pasted catchpoint label
+* a label read from a local file
+
+The latest catchpoint is downloaded from **--catchpoint-url**, where
+**<network>** is replaced with the node's network. Labels may be preceded by
+the genesis ID, for example **testnet-v1.0 30000000#HASH**, and the catchup is
+refused when it doesn't match the node. Press **a** to abort a fast catchup.
+The result is displayed in the status box. Fast catchup needs the algod admin
+token.
+
 # Accounts
 
 View all of your accounts along with recent transactions.
 
-# Configuration
+The watched accounts are listed with their balance, status and number of assets
+and applications. Press **enter** for the full account details:
+* balance, minimum balance and rewards
+* status and the rekeyed auth address
+* the registered participation key
+* asset holdings with frozen flags
+* created assets and applications
+* application local state and boxes
+* the latest transactions of the account
+* the balance history of Algos and each asset, with a sparkline of the trend
+
+Balance changes are saved in the navigator config directory, so the history is
+kept across restarts. Scroll the details to see every change, newest first.
 
-Full node configuration details.
+Press **esc** to return to the list.
+
+Press **+** to watch another address and give it a label, **-** to stop watching
+the selected address and **l** to change its label. The watch list is saved in
+the navigator config directory, addresses passed with **--watch-list** are
+added to it.
+
+Press **v** for the activity feed, the transactions which reference a watched
+account in the blocks received since the navigator started. Payments, asset
+transfers and inner transactions are included. Press **enter** to open a
+transaction in the explorer.
+
+# Alerts
+
+Alerts are defined in **alerts.json** in the navigator config directory. Each
+rule has a **kind**:
+* **balance_below** when the balance of **asset** (0 for Algos) is below **threshold**
+* **account_offline** when an account is not online
+* **key_expiry** when the participation key expires within **rounds**
+* **node_stalled** when the node makes no progress for **seconds**
+* **transaction** for every transaction of at least **threshold**
+
+Account rules apply to every watched account unless an **address** is given,
+the address must be watched. Conditions are reported when they start and when
+they are resolved.
+
+Alerts are displayed in place of the key help and ring the terminal bell. Other
+**notifiers** run a command with the alert as JSON on stdin and in **ALERT_\***
+variables, or post the JSON to a webhook. With **--tui-port** every SSH session
+evaluates the rules, so these notifiers run once per connected session.
 ` + "```json" + `
 {
-    "Version": 16,
-    "AccountsRebuildSynchronousMode": 1,
-    "AnnounceParticipationKey": true,
-    "Archival": false,
-    "BaseLoggerDebugLevel": 4,
-    "BroadcastConnectionsLimit": -1,
-    "CadaverSizeTarget": 1073741824,
-    ...
+    "rules": [
+        {"name": "low balance", "kind": "balance_below", "threshold": 1000000},
+        {"kind": "node_stalled", "seconds": 60}
+    ],
+    "notifiers": [
+        {"type": "terminal", "bell": true, "toast": true},
+        {"type": "command", "command": ["notify-send", "Algorand"]},
+        {"type": "webhook", "url": "http://localhost:8080/alerts"}
+    ]
 }
 ` + "```" + `
 
+# Configuration
+
+Edit the node's config.json. Every key algod knows about is listed
+with its current value, its default and its type. Keys which are set
+in config.json are bold.
+
+* enter: edit the selected key. Values are checked against the type of
+  the key, durations accept nanoseconds or a value like 30s.
+* d: reset the selected key to the default, removing it from config.json.
+* w: review the unsaved changes against the file and the defaults, then
+  press y to save them or n to cancel. The arrow keys scroll the review.
+* c: list the keys of config.json which are not the default for its
+  Version, with the default and the configured value. Keys which are
+  unknown or newer than the Version are listed too.
+* v: view config.json as it is on disk.
+
+Unsaved changes are marked with *. Saving keeps the previous file next
+to it as config.json.<time>.bak. algod only reads config.json when it
+starts, so when the bin directory is set you are offered a
+'goal node restart' after saving.
+
 # Help
 
 Let's be realistic for a moment, this software was so
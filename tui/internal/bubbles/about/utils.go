@@ -4,10 +4,12 @@ package about
 func GetUtilsContent() string {
 	return `## From this tab use the following keys to launch utility functions.
 
-* **F** Immediately begin a fast catchup, status is displayed.
+* **F** Pick a catchpoint and begin a fast catchup, status is displayed.
 
 * **A** Abort an ongoing fast catchup.
 
+* **K** Build a key registration transaction to bring a watched account online or offline.
+
 * **S** Send a payment transaction.
 
 * **D** Delete block from the blockchain.
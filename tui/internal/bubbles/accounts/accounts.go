@@ -2,18 +2,25 @@ package accounts
 
 import (
 	"fmt"
+	"io"
 	"sort"
 	"strings"
 	"time"
 
+	table "github.com/calyptia/go-bubble-table"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 
+	"github.com/algorand/go-algorand-sdk/v2/client/v2/common/models"
 	"github.com/algorand/go-algorand-sdk/v2/types"
 
 	"github.com/winder/algorand-navigator/messages"
+	"github.com/winder/algorand-navigator/tui/internal/bubbles/explorer"
 	"github.com/winder/algorand-navigator/tui/internal/style"
+	"github.com/winder/algorand-navigator/tui/internal/util"
 )
 
 var (
@@ -30,47 +37,152 @@ var (
 	}()
 )
 
-type balance struct {
-	MicroAlgos uint64
-	TimeStamp  time.Time
-}
+// Hacked these in to workaround missing style options in table model
+var inactiveStyle = lipgloss.NewStyle()
+var activeStyle = inactiveStyle.Copy().Foreground(lipgloss.Color("#B083EA")).Bold(true)
+
+var warningStyle = inactiveStyle.Copy().Foreground(lipgloss.Color("#E3A322")).Bold(true)
+
+var accountTableHeader = []string{"  ADDRESS", "label", "balance", "status", "assets", "apps", "rekeyed"}
+
+type state int
+
+const (
+	listState state = iota
+	detailState
+	activityState
+)
+
+// editMode is the watch list change being made.
+type editMode int
+
+const (
+	editNone editMode = iota
+	editAdd
+	editLabel
+	editRemove
+)
+
+// refreshMsg is account information fetched outside the refresh loop, for
+// example after an address is added.
+type refreshMsg messages.AccountStatusMsg
 
 type account struct {
-	Balances       map[uint64]uint64
+	Balances map[uint64]uint64
+	// BalanceHistory has every balance change, oldest first.
 	BalanceHistory []balance
+
+	// Info is the latest account information, Info.Address is empty until
+	// it has been fetched.
+	Info models.Account
 }
 
 func makeAccount() *account {
 	return &account{
 		Balances: make(map[uint64]uint64),
-		BalanceHistory: []balance{
-			{0, time.Now()},
-			{0, time.Now()},
-			{0, time.Now()},
-		}}
+	}
+}
+
+// accountItem is a row of the account table.
+type accountItem struct {
+	address types.Address
+	label   string
+	acct    *account
+}
+
+// Render implements the Row interface to display a row of data.
+func (a accountItem) Render(w io.Writer, model table.Model, index int) {
+	var cursor string
+	if index == model.Cursor() {
+		cursor = "> "
+	} else {
+		cursor = "  "
+	}
+
+	label := a.label
+	if label == "" {
+		label = "-"
+	}
+	row := fmt.Sprintf("%s%s\t%s\t-\t-\t-\t-\t-", cursor, a.address, label)
+	if info := a.acct.Info; info.Address != "" {
+		rekeyed := "-"
+		if info.AuthAddr != "" {
+			rekeyed = "yes"
+		}
+		row = fmt.Sprintf("%s%s\t%s\t%s\t%s\t%d\t%d\t%s", cursor, a.address, label, formatAlgos(info.Amount),
+			info.Status, len(info.Assets), len(info.AppsLocalState)+len(info.CreatedApps), rekeyed)
+	}
+	if index == model.Cursor() {
+		row = activeStyle.Render(row)
+	} else {
+		row = inactiveStyle.Render(row)
+	}
+	fmt.Fprintln(w, row)
 }
 
 // Model representing the account bubble.
 type Model struct {
 	accounts []types.Address
 	Accounts map[types.Address]*account
+	labels   map[types.Address]string
 
 	Err          error
 	style        *style.Styles
+	state        state
+	table        table.Model
 	viewport     viewport.Model
+	width        int
+	height       int
 	heightMargin int
 
+	// selected is the account in the detail view.
+	selected types.Address
+
+	// configDir is where the balance history is saved, it is empty until
+	// the config dir has been created.
+	configDir  string
+	historyErr error
+
+	// watch list editing
+	edit     editMode
+	editAddr types.Address
+	editErr  error
+	input    textinput.Model
+	watchErr error
+
+	// activity is the feed of transactions referencing the watched accounts,
+	// newest first. activityRound is the newest block searched.
+	activity      []explorer.Activity
+	activityRound uint64
+	activityTable table.Model
+
 	requestor *messages.Requestor
 }
 
-// New creates the accounts Model.
-func New(style *style.Styles, requestor *messages.Requestor, initialHeight int, heightMargin int, accounts []types.Address) Model {
+// New creates the accounts Model, watchErr is shown above the table until the
+// watch list changes.
+func New(style *style.Styles, requestor *messages.Requestor, initialHeight int, heightMargin int, accounts []types.Address, watchErr error) Model {
+	t := table.New(accountTableHeader, 0, 0)
+	t.KeyMap.Up.SetKeys(append(t.KeyMap.Up.Keys(), "k")...)
+	t.KeyMap.Down.SetKeys(append(t.KeyMap.Down.Keys(), "j")...)
+	t.Styles.Title = style.StatusBoldText
+
+	at := table.New(activityTableHeader, 0, 0)
+	at.KeyMap.Up.SetKeys(append(at.KeyMap.Up.Keys(), "k")...)
+	at.KeyMap.Down.SetKeys(append(at.KeyMap.Down.Keys(), "j")...)
+	at.Styles.Title = style.StatusBoldText
+
 	rval := Model{
-		Accounts:     make(map[types.Address]*account),
-		style:        style,
-		viewport:     viewport.New(0, 0),
-		heightMargin: heightMargin,
-		requestor:    requestor,
+		Accounts:      make(map[types.Address]*account),
+		labels:        make(map[types.Address]string),
+		input:         textinput.New(),
+		style:         style,
+		table:         t,
+		activityTable: at,
+		viewport:      viewport.New(0, 0),
+		heightMargin:  heightMargin,
+		requestor:     requestor,
+		watchErr:      watchErr,
 	}
 	rval.setSize(80, initialHeight)
 	rval.SetAccounts(accounts)
@@ -85,16 +197,132 @@ func (m *Model) SetAccounts(accounts []types.Address) {
 			updated[addr] = acct
 		} else {
 			updated[addr] = makeAccount()
+			m.loadHistory(addr, updated[addr])
 		}
 	}
 	m.Accounts = updated
 	m.accounts = accounts
+	m.updateTable()
 }
 
 func (m *Model) setSize(width, height int) {
+	m.width = width
+	m.height = height
+
 	footerHeight := lipgloss.Height(m.footerView())
 	m.viewport.Width = width
 	m.viewport.Height = height - m.heightMargin - footerHeight
+
+	tableHeight := height - m.heightMargin - m.style.Bottom.GetVerticalFrameSize() - lipgloss.Height(m.headerView())
+	m.table.SetSize(width-m.style.Bottom.GetHorizontalFrameSize(), tableHeight)
+
+	activityHeight := height - m.heightMargin - m.style.Bottom.GetVerticalFrameSize() - lipgloss.Height(m.activitySummary())
+	m.activityTable.SetSize(width-m.style.Bottom.GetHorizontalFrameSize(), activityHeight)
+}
+
+// updateTable rebuilds the rows in address order.
+func (m *Model) updateTable() {
+	keys := make([]types.Address, 0, len(m.Accounts))
+	for k := range m.Accounts {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].String() < keys[j].String()
+	})
+
+	rows := make([]table.Row, 0, len(keys))
+	for _, addr := range keys {
+		rows = append(rows, accountItem{address: addr, label: m.labels[addr], acct: m.Accounts[addr]})
+	}
+	m.table.SetRows(rows)
+}
+
+// loadHistory reads the saved balance history of an account, balances seen
+// before the history was loaded are added to it.
+func (m *Model) loadHistory(addr types.Address, acct *account) {
+	if m.configDir == "" {
+		return
+	}
+	history, err := loadHistory(m.configDir, addr)
+	if err != nil {
+		m.historyErr = fmt.Errorf("unable to load balance history: %w", err)
+		return
+	}
+	acct.BalanceHistory = history
+	if len(acct.Balances) > 0 {
+		m.recordBalances(addr, acct, acct.Balances)
+	}
+}
+
+// recordBalances adds any balance changes to the history of an account.
+func (m *Model) recordBalances(addr types.Address, acct *account, balances map[uint64]uint64) {
+	changes := balanceChanges(acct.BalanceHistory, balances, time.Now())
+	if len(changes) == 0 {
+		return
+	}
+	acct.BalanceHistory = append(acct.BalanceHistory, changes...)
+	if m.configDir == "" {
+		return
+	}
+	if err := appendHistory(m.configDir, addr, changes); err != nil {
+		m.historyErr = fmt.Errorf("unable to save balance history: %w", err)
+	}
+}
+
+// setWatchList changes the watched addresses, saves them and tells the other
+// tabs. Information for new addresses is fetched right away.
+func (m *Model) setWatchList(addresses []types.Address) tea.Cmd {
+	var added []types.Address
+	for _, addr := range addresses {
+		if _, ok := m.Accounts[addr]; !ok {
+			added = append(added, addr)
+		}
+	}
+	m.SetAccounts(addresses)
+	m.pruneActivity()
+
+	m.watchErr = nil
+	if m.configDir != "" {
+		if err := saveWatchList(m.configDir, m.accounts, m.labels); err != nil {
+			m.watchErr = fmt.Errorf("unable to save the watch list: %w", err)
+		}
+	}
+
+	cmds := []tea.Cmd{m.watchListCmd()}
+	if len(added) > 0 {
+		fetch := m.requestor.GetAccountStatusCmd(added)
+		cmds = append(cmds, func() tea.Msg {
+			return refreshMsg(fetch().(messages.AccountStatusMsg))
+		})
+	}
+	return tea.Batch(cmds...)
+}
+
+func (m Model) watchListCmd() tea.Cmd {
+	msg := WatchListMsg{
+		Addresses: append([]types.Address{}, m.accounts...),
+		Labels:    make(map[types.Address]string, len(m.labels)),
+	}
+	for addr, label := range m.labels {
+		msg.Labels[addr] = label
+	}
+	return func() tea.Msg {
+		return msg
+	}
+}
+
+// CapturingInput is part of the util.InputCapturer interface.
+func (m Model) CapturingInput() bool {
+	return m.edit != editNone
+}
+
+// UpdateKeys is part of the util.KeyUpdater interface.
+func (m Model) UpdateKeys(active bool) {
+	list := active && m.state == listState
+	util.AppKeys.Activity.SetEnabled(active && m.state != detailState)
+	util.AppKeys.AddWatch.SetEnabled(list)
+	util.AppKeys.RemoveWatch.SetEnabled(list && len(m.accounts) > 0)
+	util.AppKeys.LabelWatch.SetEnabled(list && len(m.accounts) > 0)
 }
 
 // Init is part of the tea.Model interface.
@@ -104,6 +332,36 @@ func (m Model) Init() tea.Cmd {
 
 // Update is part of the tea.Model interface.
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	m, cmd := m.update(msg)
+	m.setSize(m.width, m.height)
+	return m, cmd
+}
+
+// updateStatus stores fetched account information.
+func (m *Model) updateStatus(msg messages.AccountStatusMsg) {
+	m.Err = msg.Err
+	for msgAddress, info := range msg.Accounts {
+		if acct, ok := m.Accounts[msgAddress]; ok {
+			acct.Info = info
+		}
+	}
+
+	for msgAddress, msgBalances := range msg.Balances {
+		acct, ok := m.Accounts[msgAddress]
+		if !ok {
+			continue
+		}
+		m.recordBalances(msgAddress, acct, msgBalances)
+		acct.Balances = msgBalances
+	}
+
+	m.updateTable()
+	if m.state == detailState {
+		m.viewport.SetContent(m.buildString())
+	}
+}
+
+func (m Model) update(msg tea.Msg) (Model, tea.Cmd) {
 	var (
 		cmd  tea.Cmd
 		cmds []tea.Cmd
@@ -113,6 +371,30 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.WindowSizeMsg:
 		m.setSize(msg.Width, msg.Height)
 
+	case util.NavigatorUIConfigDir:
+		if msg.Err != nil || m.configDir != "" {
+			break
+		}
+		m.configDir = msg.Dir
+		for addr, acct := range m.Accounts {
+			m.loadHistory(addr, acct)
+		}
+
+		// addresses from --watch-list are added to the saved list.
+		saved, labels, err := loadWatchList(m.configDir)
+		if err != nil {
+			m.watchErr = fmt.Errorf("unable to load the watch list: %w", err)
+			return m, m.watchListCmd()
+		}
+		m.labels = labels
+		// keep the ignored --watch-list entries on screen.
+		ignored := m.watchErr
+		cmd := m.setWatchList(mergeAddresses(saved, m.accounts))
+		if m.watchErr == nil {
+			m.watchErr = ignored
+		}
+		return m, cmd
+
 	case messages.AccountStatusMsg:
 		cmds = append(cmds,
 			tea.Tick(5*time.Second, func(time.Time) tea.Msg {
@@ -120,76 +402,267 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}),
 		)
 
-		for msgAddress, msgBalances := range msg.Balances {
-			acct := m.Accounts[msgAddress]
+		m.updateStatus(msg)
+
+	case refreshMsg:
+		m.updateStatus(messages.AccountStatusMsg(msg))
 
-			// Don't update if the balance didn't change
-			if msgBalances[0] == acct.Balances[0] {
-				break
+	case explorer.BlocksMsg:
+		if msg.Err == nil {
+			m.addActivity(msg.Blocks)
+			if m.state == detailState {
+				m.viewport.SetContent(m.buildString())
 			}
+		}
 
-			newBalance := balance{
-				MicroAlgos: msgBalances[0],
-				TimeStamp:  time.Now(),
+	case tea.KeyMsg:
+		switch m.state {
+		case listState:
+			if m.edit != editNone {
+				return m.updateEdit(msg)
+			}
+			switch {
+			case key.Matches(msg, util.AppKeys.Activity):
+				m.state = activityState
+				return m, nil
+			case key.Matches(msg, util.AppKeys.AddWatch):
+				return m.startEdit(editAdd, types.Address{}, "")
+			case key.Matches(msg, util.AppKeys.RemoveWatch):
+				if item, ok := m.selectedRow(); ok {
+					return m.startEdit(editRemove, item.address, "")
+				}
+				return m, nil
+			case key.Matches(msg, util.AppKeys.LabelWatch):
+				if item, ok := m.selectedRow(); ok {
+					return m.startEdit(editLabel, item.address, item.label)
+				}
+				return m, nil
+			}
+			if key.Matches(msg, util.AppKeys.Forward) {
+				if item, ok := m.selectedRow(); ok {
+					m.selected = item.address
+					m.state = detailState
+					m.viewport.SetContent(m.buildString())
+					m.viewport.GotoTop()
+				}
+				return m, nil
+			}
+			m.table, cmd = m.table.Update(msg)
+			return m, cmd
+		case detailState:
+			if key.Matches(msg, util.AppKeys.Back) {
+				m.state = listState
+				return m, nil
 			}
+		case activityState:
+			switch {
+			case key.Matches(msg, util.AppKeys.Back), key.Matches(msg, util.AppKeys.Activity):
+				m.state = listState
+				return m, nil
+			case key.Matches(msg, util.AppKeys.Forward):
+				if item, ok := m.selectedActivity(); ok {
+					show := explorer.ShowTxnMsg{Round: item.Round, Intra: item.Intra, Path: item.Path}
+					return m, func() tea.Msg {
+						return show
+					}
+				}
+				return m, nil
+			}
+			m.activityTable, cmd = m.activityTable.Update(msg)
+			return m, cmd
+		}
+	}
+
+	if m.state == detailState {
+		m.viewport, cmd = m.viewport.Update(msg)
+		cmds = append(cmds, cmd)
+	}
+
+	return m, tea.Batch(cmds...)
+}
+
+// startEdit opens the prompt for a watch list change.
+func (m Model) startEdit(mode editMode, addr types.Address, value string) (Model, tea.Cmd) {
+	m.edit = mode
+	m.editAddr = addr
+	m.editErr = nil
+	if mode == editRemove {
+		m.input.Blur()
+		return m, nil
+	}
+	if mode == editAdd {
+		m.input.Placeholder = "address"
+	} else {
+		m.input.Placeholder = "label, leave empty to remove it"
+	}
+	m.input.SetValue(value)
+	m.input.CursorEnd()
+	return m, m.input.Focus()
+}
 
-			// Prepend the balance
-			tmpList := append([]balance{newBalance}, acct.BalanceHistory...)
-			if len(tmpList) > 3 {
-				tmpList = tmpList[:3]
+func (m Model) stopEdit() Model {
+	m.edit = editNone
+	m.editErr = nil
+	m.input.Blur()
+	return m
+}
+
+// updateEdit handles keys while a watch list change is being made.
+func (m Model) updateEdit(msg tea.KeyMsg) (Model, tea.Cmd) {
+	if m.edit == editRemove {
+		addr := m.editAddr
+		m = m.stopEdit()
+		if msg.String() != "y" && msg.String() != "Y" {
+			return m, nil
+		}
+		addresses := make([]types.Address, 0, len(m.accounts))
+		for _, a := range m.accounts {
+			if a != addr {
+				addresses = append(addresses, a)
 			}
-			acct.BalanceHistory = tmpList
-			acct.Balances = msgBalances
+		}
+		delete(m.labels, addr)
+		return m, m.setWatchList(addresses)
+	}
 
-			m.Accounts[msgAddress] = acct
+	switch msg.Type {
+	case tea.KeyEsc:
+		return m.stopEdit(), nil
+	case tea.KeyEnter:
+		switch m.edit {
+		case editAdd:
+			addr, err := parseWatchAddress(m.input.Value(), m.accounts)
+			if err != nil {
+				m.editErr = err
+				return m, nil
+			}
+			cmd := m.setWatchList(append(append([]types.Address{}, m.accounts...), addr))
+			// continue with the label of the new address.
+			m, focus := m.startEdit(editLabel, addr, "")
+			return m, tea.Batch(cmd, focus)
+		case editLabel:
+			label, err := parseLabel(m.input.Value())
+			if err != nil {
+				m.editErr = err
+				return m, nil
+			}
+			if label == "" {
+				delete(m.labels, m.editAddr)
+			} else {
+				m.labels[m.editAddr] = label
+			}
+			m = m.stopEdit()
+			return m, m.setWatchList(m.accounts)
 		}
+	}
 
-		m.viewport.SetContent(m.buildString())
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd
+}
+
+// editView is the prompt of the watch list change being made.
+func (m Model) editView() string {
+	bold := m.style.StatusBoldText
+	var b strings.Builder
+	switch m.edit {
+	case editNone:
+		return ""
+	case editRemove:
+		name := m.editAddr.String()
+		if label := m.labels[m.editAddr]; label != "" {
+			name = fmt.Sprintf("%s (%s)", label, name)
+		}
+		fmt.Fprintf(&b, "Stop watching %s? The balance history is kept.\n", name)
+		b.WriteString(bold.Render("Press y to confirm, any other key to cancel."))
+		return b.String()
+	case editAdd:
+		b.WriteString(bold.Render("Watch address:"))
+	case editLabel:
+		b.WriteString(bold.Render(fmt.Sprintf("Label for %s:", m.editAddr)))
+	}
+	b.WriteString("\n")
+	b.WriteString(m.input.View())
+	if m.editErr != nil {
+		b.WriteString("\n")
+		b.WriteString(warningStyle.Render("Error: " + m.editErr.Error()))
 	}
+	b.WriteString("\n")
+	b.WriteString("enter: save, esc: cancel")
+	return b.String()
+}
 
-	m.viewport, cmd = m.viewport.Update(msg)
-	cmds = append(cmds, cmd)
+// headerView is displayed above the account table.
+func (m Model) headerView() string {
+	if edit := m.editView(); edit != "" {
+		return lipgloss.JoinVertical(0, m.summaryView(), "", edit, "")
+	}
+	return m.summaryView()
+}
 
-	return m, tea.Batch(cmds...)
+// selectedRow returns the row under the table cursor.
+func (m Model) selectedRow() (accountItem, bool) {
+	if len(m.Accounts) == 0 {
+		return accountItem{}, false
+	}
+	item, ok := m.table.SelectedRow().(accountItem)
+	return item, ok
 }
 
 // View is part of the tea.Model interface.
 func (m Model) View() string {
+	switch m.state {
+	case listState:
+		return m.style.Bottom.Render(lipgloss.JoinVertical(0, m.headerView(), m.table.View()))
+	case activityState:
+		return m.style.Bottom.Render(lipgloss.JoinVertical(0, m.activitySummary(), m.activityTable.View()))
+	}
 
 	builder := strings.Builder{}
 	builder.WriteString(fmt.Sprintf("%s\n%s", m.viewport.View(), m.footerView()))
 	return builder.String()
 }
 
+// summaryView is displayed above the account table.
+func (m Model) summaryView() string {
+	switch {
+	case m.Err != nil:
+		return fmt.Sprintf("Error: %s", strings.ReplaceAll(m.Err.Error(), "\n", " "))
+	case m.watchErr != nil:
+		return fmt.Sprintf("Error: %s", strings.ReplaceAll(m.watchErr.Error(), "\n", " "))
+	case m.historyErr != nil:
+		return fmt.Sprintf("Error: %s", strings.ReplaceAll(m.historyErr.Error(), "\n", " "))
+	case len(m.Accounts) == 0:
+		return "No accounts are being watched, press + to add one."
+	}
+	return fmt.Sprintf("Watching %d accounts, press enter for details, v for recent transactions, + to add, - to remove and l to label.", len(m.Accounts))
+}
+
+// buildString renders the details of the selected account.
 func (m Model) buildString() string {
 	builder := strings.Builder{}
 
-	keys := make([]string, 0, len(m.Accounts))
-	for k := range m.Accounts {
-		keys = append(keys, k.String())
+	acct, ok := m.Accounts[m.selected]
+	if !ok {
+		return m.style.Account.Render("The account is no longer watched.")
+	}
+	if acct.Info.Address == "" {
+		builder.WriteString(fmt.Sprintf("%s %s\n", m.style.AccountBoldText.Render("Account:"), m.style.AccountYellowText.Render(m.selected.String())))
+		builder.WriteString("Loading account information...\n")
+		return m.style.Account.Render(builder.String())
 	}
-	sort.Strings(keys)
-
-	for _, account := range keys {
-		accountType, _ := types.DecodeAddress(account)
-		v := m.Accounts[accountType]
-		builder.WriteString(fmt.Sprintf("%s %s\n", m.style.AccountBoldText.Render("Account:"), m.style.AccountYellowText.Render(account)))
-
-		algoStr := fmt.Sprintf("         %f Algos", float64(v.Balances[0])/1000000.0)
-		builder.WriteString(m.style.AccountBlueText.Render(algoStr) + "\n")
-		for _, a := range v.BalanceHistory {
-			if a.MicroAlgos == 0 {
-				builder.WriteString("\n")
-			} else {
-				pastStr := fmt.Sprintf("         %f Algos @ %s\n", float64(a.MicroAlgos)/1000000, a.TimeStamp.Format("2006-01-02 15:04:05.1234"))
-				builder.WriteString(pastStr)
-			}
-		}
 
+	if label := m.labels[m.selected]; label != "" {
+		builder.WriteString(fmt.Sprintf("%s %s\n\n", m.style.AccountBoldText.Render("Label:"), m.style.AccountYellowText.Render(label)))
 	}
+	builder.WriteString(accountDetails(acct.Info, m.style.AccountBoldText))
+	builder.WriteString(m.accountActivity(m.selected))
+
+	builder.WriteString(historyView(acct.BalanceHistory, m.style.AccountBoldText, m.viewport.Width-m.style.Account.GetHorizontalFrameSize()))
 
 	return m.style.Account.Render(builder.String())
 }
+
 func (m Model) footerView() string {
 	info := infoStyle.Render(fmt.Sprintf("%3.f%%", m.viewport.ScrollPercent()*100))
 	line := strings.Repeat("─", max(0, m.viewport.Width-lipgloss.Width(info)))
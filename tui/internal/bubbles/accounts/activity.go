@@ -0,0 +1,209 @@
+package accounts
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	table "github.com/calyptia/go-bubble-table"
+
+	"github.com/algorand/go-algorand-sdk/v2/types"
+
+	"github.com/winder/algorand-navigator/tui/internal/bubbles/explorer"
+)
+
+// maxActivity caps the activity feed so that a long session doesn't grow
+// forever.
+const maxActivity = 1000
+
+// detailActivity is the number of transactions in the account details.
+const detailActivity = 10
+
+var activityTableHeader = []string{"  ROUND", "time", "type", "amount", "account", "sender", "receiver", "id"}
+
+// activityItem is a row of the activity table.
+type activityItem struct {
+	explorer.Activity
+	account string
+}
+
+// Render implements the Row interface to display a row of data.
+func (a activityItem) Render(w io.Writer, model table.Model, index int) {
+	var cursor string
+	if index == model.Cursor() {
+		cursor = "> "
+	} else {
+		cursor = "  "
+	}
+
+	id := a.TxID
+	if label := a.InnerLabel(); label != "" {
+		id = fmt.Sprintf("%s (%s)", id, label)
+	}
+	row := fmt.Sprintf("%s%d\t%s\t%s\t%s\t%s\t%s\t%s\t%s", cursor, a.Round, a.Time.Format("15:04:05"), a.Type,
+		activityAmount(a.Activity), a.account, shortAddress(a.Sender), shortAddress(a.Receiver), id)
+	if index == model.Cursor() {
+		row = activeStyle.Render(row)
+	} else {
+		row = inactiveStyle.Render(row)
+	}
+	fmt.Fprintln(w, row)
+}
+
+func shortAddress(addr types.Address) string {
+	if addr.IsZero() {
+		return "-"
+	}
+	s := addr.String()
+	return s[:6] + "…" + s[len(s)-4:]
+}
+
+func activityAmount(a explorer.Activity) string {
+	switch a.Type {
+	case types.PaymentTx:
+		return formatAlgos(a.Amount)
+	case types.AssetTransferTx:
+		return fmt.Sprintf("%d ASA %d", a.Amount, a.Asset)
+	}
+	return "-"
+}
+
+// addActivity adds the watched transactions of new blocks to the feed, newest
+// first. Blocks older than the newest block already seen are skipped, they
+// are pages of history loaded by the explorer.
+func (m *Model) addActivity(blks []explorer.BlockItem) {
+	watched := make(map[types.Address]bool, len(m.accounts))
+	for _, addr := range m.accounts {
+		watched[addr] = true
+	}
+
+	latest := m.activityRound
+	var found []explorer.Activity
+	// blocks arrive newest first.
+	for i := len(blks) - 1; i >= 0; i-- {
+		blk := blks[i]
+		if blk.Round <= m.activityRound {
+			continue
+		}
+		if blk.Round > latest {
+			latest = blk.Round
+		}
+		found = append(found, explorer.FindActivity(blk, watched)...)
+	}
+	m.activityRound = latest
+	if len(found) == 0 {
+		return
+	}
+
+	feed := make([]explorer.Activity, 0, len(found)+len(m.activity))
+	for i := len(found) - 1; i >= 0; i-- {
+		feed = append(feed, found[i])
+	}
+	feed = append(feed, m.activity...)
+	if len(feed) > maxActivity {
+		feed = feed[:maxActivity]
+	}
+	m.activity = feed
+	m.updateActivityTable()
+}
+
+// pruneActivity removes the transactions which don't reference any watched
+// address, after an address is removed from the watch list.
+func (m *Model) pruneActivity() {
+	feed := m.activity[:0]
+	for _, a := range m.activity {
+		var watched []types.Address
+		for _, addr := range a.Watched {
+			if _, ok := m.Accounts[addr]; ok {
+				watched = append(watched, addr)
+			}
+		}
+		if len(watched) > 0 {
+			a.Watched = watched
+			feed = append(feed, a)
+		}
+	}
+	m.activity = feed
+	m.updateActivityTable()
+}
+
+// accountName is the label of a watched address, or a short address.
+func (m Model) accountName(addr types.Address) string {
+	if label := m.labels[addr]; label != "" {
+		return label
+	}
+	return shortAddress(addr)
+}
+
+func (m *Model) updateActivityTable() {
+	rows := make([]table.Row, 0, len(m.activity))
+	for _, a := range m.activity {
+		names := make([]string, 0, len(a.Watched))
+		for _, addr := range a.Watched {
+			names = append(names, m.accountName(addr))
+		}
+		rows = append(rows, activityItem{Activity: a, account: strings.Join(names, ", ")})
+	}
+	m.activityTable.SetRows(rows)
+}
+
+// selectedActivity returns the row under the activity table cursor.
+func (m Model) selectedActivity() (activityItem, bool) {
+	if len(m.activity) == 0 {
+		return activityItem{}, false
+	}
+	item, ok := m.activityTable.SelectedRow().(activityItem)
+	return item, ok
+}
+
+// activitySummary is displayed above the activity table.
+func (m Model) activitySummary() string {
+	switch {
+	case len(m.accounts) == 0:
+		return "No accounts are being watched, press esc and + to add one."
+	case len(m.activity) == 0:
+		return "No transactions for the watched accounts since the navigator started."
+	}
+	return fmt.Sprintf("%d transactions for the watched accounts, press enter to open one in the explorer.", len(m.activity))
+}
+
+// accountActivity lists the latest transactions of an account.
+func (m Model) accountActivity(addr types.Address) string {
+	var b strings.Builder
+	var lines []string
+	for _, a := range m.activity {
+		if len(lines) == detailActivity {
+			break
+		}
+		for _, w := range a.Watched {
+			if w != addr {
+				continue
+			}
+			direction := "  "
+			switch {
+			case a.Sender == addr:
+				direction = "→ "
+			case a.Receiver == addr:
+				direction = "← "
+			}
+			id := a.TxID
+			if label := a.InnerLabel(); label != "" {
+				id += " (" + label + ")"
+			}
+			lines = append(lines, fmt.Sprintf("  %s %-10s %s%-7s %-22s %s",
+				a.Time.Format("15:04:05"), strconv.FormatUint(a.Round, 10), direction, a.Type, activityAmount(a), id))
+			break
+		}
+	}
+
+	writeHeading(&b, m.style.AccountBoldText, "Recent transactions", len(lines))
+	if len(lines) == 0 {
+		b.WriteString("  No transactions since the navigator started.\n")
+	}
+	for _, line := range lines {
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,142 @@
+package accounts
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/algorand/go-algorand-sdk/v2/client/v2/common/models"
+)
+
+// Minimum balance requirements, in microAlgos. These have not changed since
+// they were introduced, the node version used by the SDK does not return the
+// minimum balance so it is computed from them.
+const (
+	minBalance              = 100_000
+	appFlatParamsMinBalance = 100_000
+	appFlatOptInMinBalance  = 100_000
+	schemaEntryMinBalance   = 25_000
+	schemaUintMinBalance    = 3_500
+	schemaBytesMinBalance   = 25_000
+	boxFlatMinBalance       = 2_500
+	boxByteMinBalance       = 400
+)
+
+// accountMinBalance computes the minimum balance of an account.
+func accountMinBalance(acct models.Account) uint64 {
+	schema := acct.AppsTotalSchema
+	total := uint64(minBalance)
+	total += minBalance * acct.TotalAssetsOptedIn
+	total += appFlatOptInMinBalance * acct.TotalAppsOptedIn
+	total += appFlatParamsMinBalance * (acct.TotalCreatedApps + acct.AppsTotalExtraPages)
+	total += (schemaEntryMinBalance + schemaUintMinBalance) * schema.NumUint
+	total += (schemaEntryMinBalance + schemaBytesMinBalance) * schema.NumByteSlice
+	total += boxFlatMinBalance*acct.TotalBoxes + boxByteMinBalance*acct.TotalBoxBytes
+	return total
+}
+
+func formatAlgos(microAlgos uint64) string {
+	return fmt.Sprintf("%f Algos", float64(microAlgos)/1000000.0)
+}
+
+func b64(b []byte) string {
+	if len(b) == 0 {
+		return "(none)"
+	}
+	return base64.StdEncoding.EncodeToString(b)
+}
+
+func writeField(b *strings.Builder, name string, value interface{}) {
+	fmt.Fprintf(b, "  %-25s %v\n", name+":", value)
+}
+
+func writeHeading(b *strings.Builder, heading lipgloss.Style, title string, count int) {
+	b.WriteString("\n")
+	if count < 0 {
+		b.WriteString(heading.Render(title))
+	} else {
+		b.WriteString(heading.Render(fmt.Sprintf("%s (%d)", title, count)))
+	}
+	b.WriteString("\n")
+}
+
+// accountDetails renders every field of an account.
+func accountDetails(acct models.Account, heading lipgloss.Style) string {
+	var b strings.Builder
+
+	b.WriteString(heading.Render("Account"))
+	b.WriteString("\n")
+	writeField(&b, "Address", acct.Address)
+	writeField(&b, "Round", acct.Round)
+	writeField(&b, "Status", acct.Status)
+	if acct.SigType != "" {
+		writeField(&b, "Signature type", acct.SigType)
+	}
+	if acct.AuthAddr != "" {
+		writeField(&b, "Rekeyed to", acct.AuthAddr)
+	} else {
+		writeField(&b, "Rekeyed to", "(not rekeyed)")
+	}
+
+	writeHeading(&b, heading, "Balance", -1)
+	minBal := accountMinBalance(acct)
+	var spendable uint64
+	if acct.Amount > minBal {
+		spendable = acct.Amount - minBal
+	}
+	writeField(&b, "Balance", formatAlgos(acct.Amount))
+	writeField(&b, "Minimum balance", formatAlgos(minBal))
+	writeField(&b, "Spendable", formatAlgos(spendable))
+	writeField(&b, "Pending rewards", formatAlgos(acct.PendingRewards))
+	writeField(&b, "Without pending rewards", formatAlgos(acct.AmountWithoutPendingRewards))
+	writeField(&b, "Total rewards", formatAlgos(acct.Rewards))
+
+	writeHeading(&b, heading, "Participation", -1)
+	part := acct.Participation
+	if part.VoteLastValid == 0 {
+		b.WriteString("  No participation key is registered.\n")
+	} else {
+		writeField(&b, "Vote first valid", part.VoteFirstValid)
+		writeField(&b, "Vote last valid", part.VoteLastValid)
+		writeField(&b, "Vote key dilution", part.VoteKeyDilution)
+		writeField(&b, "Vote key", b64(part.VoteParticipationKey))
+		writeField(&b, "Selection key", b64(part.SelectionParticipationKey))
+		writeField(&b, "State proof key", b64(part.StateProofKey))
+	}
+
+	writeHeading(&b, heading, "Asset holdings", len(acct.Assets))
+	for _, holding := range acct.Assets {
+		frozen := ""
+		if holding.IsFrozen {
+			frozen = "  frozen"
+		}
+		fmt.Fprintf(&b, "  %-12d %d%s\n", holding.AssetId, holding.Amount, frozen)
+	}
+
+	writeHeading(&b, heading, "Created assets", len(acct.CreatedAssets))
+	for _, asset := range acct.CreatedAssets {
+		p := asset.Params
+		fmt.Fprintf(&b, "  %-12d %-8s %s, total %d, decimals %d\n", asset.Index, p.UnitName, p.Name, p.Total, p.Decimals)
+	}
+
+	writeHeading(&b, heading, "Created applications", len(acct.CreatedApps))
+	for _, app := range acct.CreatedApps {
+		p := app.Params
+		fmt.Fprintf(&b, "  %-12d global schema %d uints, %d byte slices, %d extra pages\n",
+			app.Id, p.GlobalStateSchema.NumUint, p.GlobalStateSchema.NumByteSlice, p.ExtraProgramPages)
+	}
+
+	writeHeading(&b, heading, "Application local state", len(acct.AppsLocalState))
+	for _, local := range acct.AppsLocalState {
+		fmt.Fprintf(&b, "  %-12d %d keys, schema %d uints, %d byte slices\n",
+			local.Id, len(local.KeyValue), local.Schema.NumUint, local.Schema.NumByteSlice)
+	}
+
+	writeHeading(&b, heading, "Boxes", -1)
+	writeField(&b, "Boxes", acct.TotalBoxes)
+	writeField(&b, "Box bytes", acct.TotalBoxBytes)
+
+	return b.String()
+}
@@ -0,0 +1,178 @@
+package accounts
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/algorand/go-algorand-sdk/v2/types"
+
+	"github.com/winder/algorand-navigator/tui/internal/util"
+)
+
+// historyDir is the directory in the config dir with the balance history of
+// each account, one file per account with one JSON entry per line.
+const historyDir = "history"
+
+// balance is a balance change of an asset, the asset is 0 for Algos.
+type balance struct {
+	Asset     uint64    `json:"asset"`
+	Amount    uint64    `json:"amount"`
+	TimeStamp time.Time `json:"time"`
+}
+
+func historyFile(configDir string, addr types.Address) string {
+	return path.Join(configDir, historyDir, addr.String()+".jsonl")
+}
+
+// loadHistory reads the balance history of an account, a missing file is an
+// empty history.
+func loadHistory(configDir string, addr types.Address) ([]balance, error) {
+	f, err := os.Open(historyFile(configDir, addr))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var history []balance
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var b balance
+		// skip a partially written line rather than losing the history.
+		if err := json.Unmarshal(scanner.Bytes(), &b); err == nil {
+			history = append(history, b)
+		}
+	}
+	return history, scanner.Err()
+}
+
+// appendHistory adds balance changes to the history file of an account.
+func appendHistory(configDir string, addr types.Address, changes []balance) error {
+	if err := os.MkdirAll(path.Join(configDir, historyDir), 0755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(historyFile(configDir, addr), os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	for _, b := range changes {
+		line, err := json.Marshal(b)
+		if err != nil {
+			f.Close()
+			return err
+		}
+		if _, err := f.Write(append(line, '\n')); err != nil {
+			f.Close()
+			return err
+		}
+	}
+	return f.Close()
+}
+
+// latestBalances returns the last recorded amount of each asset.
+func latestBalances(history []balance) map[uint64]uint64 {
+	latest := make(map[uint64]uint64)
+	for _, b := range history {
+		latest[b.Asset] = b.Amount
+	}
+	return latest
+}
+
+// balanceChanges compares new balances to the history. Assets which are no
+// longer held are recorded with a zero balance.
+func balanceChanges(history []balance, balances map[uint64]uint64, now time.Time) []balance {
+	latest := latestBalances(history)
+	var changes []balance
+	for asset, amount := range balances {
+		if last, ok := latest[asset]; !ok || last != amount {
+			changes = append(changes, balance{Asset: asset, Amount: amount, TimeStamp: now})
+		}
+	}
+	for asset, last := range latest {
+		if _, ok := balances[asset]; !ok && last != 0 {
+			changes = append(changes, balance{Asset: asset, Amount: 0, TimeStamp: now})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Asset < changes[j].Asset
+	})
+	return changes
+}
+
+func assetName(asset uint64) string {
+	if asset == 0 {
+		return "ALGO"
+	}
+	return fmt.Sprintf("ASA %d", asset)
+}
+
+func formatAmount(asset, amount uint64) string {
+	if asset == 0 {
+		return formatAlgos(amount)
+	}
+	return fmt.Sprintf("%d", amount)
+}
+
+// historyView renders a sparkline for each asset followed by every change,
+// newest first.
+func historyView(history []balance, heading lipgloss.Style, width int) string {
+	var b strings.Builder
+	writeHeading(&b, heading, "Balance history", len(history))
+	if len(history) == 0 {
+		b.WriteString("  No balance changes recorded yet.\n")
+		return b.String()
+	}
+
+	series := make(map[uint64][]float64)
+	var assets []uint64
+	for _, h := range history {
+		if _, ok := series[h.Asset]; !ok {
+			assets = append(assets, h.Asset)
+		}
+		series[h.Asset] = append(series[h.Asset], float64(h.Amount))
+	}
+	sort.Slice(assets, func(i, j int) bool { return assets[i] < assets[j] })
+
+	const labelWidth = 16
+	for _, asset := range assets {
+		values := series[asset]
+		sparkWidth := width - labelWidth - 4
+		if len(values) < sparkWidth {
+			sparkWidth = len(values)
+		}
+		fmt.Fprintf(&b, "  %-*s %s\n", labelWidth, assetName(asset), util.Sparkline(values, sparkWidth))
+	}
+	b.WriteString("\n")
+
+	previous := make(map[uint64]uint64)
+	lines := make([]string, 0, len(history))
+	for _, h := range history {
+		change := ""
+		if last, ok := previous[h.Asset]; ok {
+			if h.Amount >= last {
+				change = "+" + formatAmount(h.Asset, h.Amount-last)
+			} else {
+				change = "-" + formatAmount(h.Asset, last-h.Amount)
+			}
+			change = "(" + change + ")"
+		}
+		previous[h.Asset] = h.Amount
+		lines = append(lines, strings.TrimRight(fmt.Sprintf("  %s  %-10s %s %s",
+			h.TimeStamp.Format("2006-01-02 15:04:05"), assetName(h.Asset), formatAmount(h.Asset, h.Amount), change), " "))
+	}
+	for i := len(lines) - 1; i >= 0; i-- {
+		b.WriteString(lines[i])
+		b.WriteString("\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,142 @@
+package accounts
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/algorand/go-algorand-sdk/v2/types"
+)
+
+// watchListFile is the file in the config dir with the watched addresses.
+const watchListFile = "watchlist.json"
+
+// WatchListMsg is sent when the watched addresses change.
+type WatchListMsg struct {
+	Addresses []types.Address
+	Labels    map[types.Address]string
+}
+
+// watchEntry is an address book entry in the watch list file.
+type watchEntry struct {
+	Address string `json:"address"`
+	Label   string `json:"label,omitempty"`
+}
+
+// loadWatchList reads the saved watch list, a missing file is an empty list.
+func loadWatchList(configDir string) ([]types.Address, map[types.Address]string, error) {
+	labels := make(map[types.Address]string)
+	data, err := os.ReadFile(path.Join(configDir, watchListFile))
+	if os.IsNotExist(err) {
+		return nil, labels, nil
+	}
+	if err != nil {
+		return nil, labels, err
+	}
+
+	var entries []watchEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, labels, fmt.Errorf("%s is malformed: %w", watchListFile, err)
+	}
+	addresses := make([]types.Address, 0, len(entries))
+	for _, e := range entries {
+		addr, err := types.DecodeAddress(e.Address)
+		if err != nil {
+			return nil, labels, fmt.Errorf("%s has a bad address '%s': %w", watchListFile, e.Address, err)
+		}
+		addresses = append(addresses, addr)
+		if e.Label != "" {
+			labels[addr] = e.Label
+		}
+	}
+	return addresses, labels, nil
+}
+
+// saveWatchList replaces the watch list file. It is written to a temporary
+// file first so that a failed write doesn't lose the list.
+func saveWatchList(configDir string, addresses []types.Address, labels map[types.Address]string) error {
+	entries := make([]watchEntry, 0, len(addresses))
+	for _, addr := range addresses {
+		entries = append(entries, watchEntry{Address: addr.String(), Label: labels[addr]})
+	}
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	file := path.Join(configDir, watchListFile)
+	if err := os.WriteFile(file+".tmp", append(data, '\n'), 0644); err != nil {
+		return err
+	}
+	return os.Rename(file+".tmp", file)
+}
+
+// mergeAddresses adds the addresses missing from the list, keeping the order.
+func mergeAddresses(list []types.Address, extra []types.Address) []types.Address {
+	result := append([]types.Address{}, list...)
+	for _, addr := range extra {
+		if indexOf(result, addr) < 0 {
+			result = append(result, addr)
+		}
+	}
+	return result
+}
+
+func indexOf(list []types.Address, addr types.Address) int {
+	for i, a := range list {
+		if a == addr {
+			return i
+		}
+	}
+	return -1
+}
+
+// parseWatchAddress validates an address typed into the add prompt.
+func parseWatchAddress(input string, watched []types.Address) (types.Address, error) {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return types.Address{}, errors.New("enter an address")
+	}
+	addr, err := types.DecodeAddress(input)
+	if err != nil {
+		return types.Address{}, fmt.Errorf("'%s' is not a valid address: %w", input, err)
+	}
+	if indexOf(watched, addr) >= 0 {
+		return types.Address{}, fmt.Errorf("%s is already watched", addr)
+	}
+	return addr, nil
+}
+
+// ParseWatchList decodes the --watch-list addresses. Invalid entries are
+// skipped, err describes them with the message of the add prompt.
+func ParseWatchList(input []string) (addresses []types.Address, err error) {
+	var invalid []string
+	for _, s := range input {
+		if strings.TrimSpace(s) == "" {
+			continue
+		}
+		addr, perr := parseWatchAddress(s, nil)
+		switch {
+		case perr != nil:
+			invalid = append(invalid, perr.Error())
+		case indexOf(addresses, addr) < 0:
+			addresses = append(addresses, addr)
+		}
+	}
+	if len(invalid) > 0 {
+		err = fmt.Errorf("ignored --watch-list entries: %s", strings.Join(invalid, "; "))
+	}
+	return addresses, err
+}
+
+// parseLabel validates a label typed into the label prompt.
+func parseLabel(input string) (string, error) {
+	label := strings.TrimSpace(input)
+	if strings.ContainsAny(label, "\t\n") {
+		return "", errors.New("labels can't contain tabs or new lines")
+	}
+	return label, nil
+}
@@ -0,0 +1,186 @@
+// Package alerts evaluates the alert rules from the config dir against the
+// node and account updates, and sends the alerts to the configured notifiers.
+package alerts
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/winder/algorand-navigator/messages"
+	"github.com/winder/algorand-navigator/tui/internal/bubbles/accounts"
+	"github.com/winder/algorand-navigator/tui/internal/bubbles/explorer"
+	"github.com/winder/algorand-navigator/tui/internal/util"
+)
+
+// toastDuration is how long an alert is displayed.
+const toastDuration = 10 * time.Second
+
+var firingStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#E3A322")).Bold(true)
+var resolvedStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#2AB56F")).Bold(true)
+
+// notifiedMsg has the errors of the notifiers.
+type notifiedMsg struct {
+	errs []error
+}
+
+// toastExpiredMsg hides a toast once it has been displayed long enough.
+type toastExpiredMsg struct {
+	id int
+}
+
+// Model for the alerts. It doesn't have a tab, the View is a one line toast
+// which is empty while there is nothing to display.
+type Model struct {
+	width int
+
+	engine    engine
+	notifiers []Notifier
+	toast     bool
+	// bell is the output of the program. Over SSH every session has its own
+	// Model, so command and webhook notifiers run once per session.
+	bell io.Writer
+
+	toastText  string
+	toastStyle lipgloss.Style
+	toastID    int
+}
+
+// New creates the alerts Model, there are no rules until the config dir is
+// known. The terminal bell is written to out.
+func New(out io.Writer) Model {
+	return Model{
+		engine: newEngine(nil),
+		bell:   out,
+	}
+}
+
+// Init is part of the tea.Model interface.
+func (m Model) Init() tea.Cmd {
+	return nil
+}
+
+// Update is part of the tea.Model interface.
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	now := time.Now()
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+
+	case util.NavigatorUIConfigDir:
+		if msg.Err != nil || msg.Dir == "" {
+			break
+		}
+		return m.configure(msg.Dir)
+
+	case accounts.WatchListMsg:
+		m.engine.watched = msg.Addresses
+		m.engine.labels = msg.Labels
+
+	case messages.StatusMsg:
+		return m.notify(m.engine.status(msg, now))
+
+	case messages.AccountStatusMsg:
+		return m.notify(m.engine.accounts(msg, now))
+
+	case explorer.BlocksMsg:
+		return m.notify(m.engine.blocks(msg, now))
+
+	case notifiedMsg:
+		if len(msg.errs) > 0 {
+			errs := make([]string, 0, len(msg.errs))
+			for _, err := range msg.errs {
+				errs = append(errs, err.Error())
+			}
+			return m.showToast("Unable to send alert: "+strings.Join(errs, ", "), firingStyle)
+		}
+
+	case toastExpiredMsg:
+		if msg.id == m.toastID {
+			m.toastText = ""
+		}
+	}
+	return m, nil
+}
+
+// configure loads the rules and notifiers, problems are displayed as a toast.
+func (m Model) configure(configDir string) (Model, tea.Cmd) {
+	cfg, err := loadConfig(configDir)
+	if err == nil {
+		m.notifiers, m.toast, err = makeNotifiers(cfg.Notifiers, m.bell)
+	}
+	if err != nil {
+		m.toast = true
+		return m.showToast(fmt.Sprintf("Alerts are disabled: %s", err), firingStyle)
+	}
+
+	watched, labels := m.engine.watched, m.engine.labels
+	m.engine = newEngine(cfg.Rules)
+	m.engine.watched, m.engine.labels = watched, labels
+	return m, nil
+}
+
+// notify sends the alerts to every notifier in the background and displays
+// the newest one.
+func (m Model) notify(alerts []Alert) (Model, tea.Cmd) {
+	if len(alerts) == 0 {
+		return m, nil
+	}
+
+	notifiers := m.notifiers
+	send := func() tea.Msg {
+		var errs []error
+		for _, alert := range alerts {
+			for _, n := range notifiers {
+				ctx, cancel := context.WithTimeout(context.Background(), notifyTimeout)
+				if err := n.Notify(ctx, alert); err != nil {
+					errs = append(errs, fmt.Errorf("%s: %w", n.Name(), err))
+				}
+				cancel()
+			}
+		}
+		return notifiedMsg{errs: errs}
+	}
+
+	latest := alerts[len(alerts)-1]
+	text := "Alert: " + latest.String()
+	if len(alerts) > 1 {
+		text = fmt.Sprintf("%s (+%d more)", text, len(alerts)-1)
+	}
+	style := firingStyle
+	if latest.State == Resolved {
+		style = resolvedStyle
+	}
+	m, toast := m.showToast(text, style)
+	return m, tea.Batch(send, toast)
+}
+
+func (m Model) showToast(text string, style lipgloss.Style) (Model, tea.Cmd) {
+	if !m.toast {
+		return m, nil
+	}
+	m.toastID++
+	m.toastText = strings.ReplaceAll(text, "\n", " ")
+	m.toastStyle = style
+	id := m.toastID
+	return m, tea.Tick(toastDuration, func(time.Time) tea.Msg {
+		return toastExpiredMsg{id: id}
+	})
+}
+
+// View is part of the tea.Model interface.
+func (m Model) View() string {
+	if m.toastText == "" {
+		return ""
+	}
+	style := m.toastStyle
+	if m.width > 0 {
+		style = style.Copy().MaxWidth(m.width)
+	}
+	return style.Render(m.toastText)
+}
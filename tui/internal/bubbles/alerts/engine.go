@@ -0,0 +1,237 @@
+package alerts
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/algorand/go-algorand-sdk/v2/types"
+
+	"github.com/winder/algorand-navigator/messages"
+	"github.com/winder/algorand-navigator/tui/internal/bubbles/explorer"
+)
+
+// engine evaluates the rules. Conditions only notify when they start and
+// stop, transactions notify every time.
+type engine struct {
+	rules   []Rule
+	watched []types.Address
+	labels  map[types.Address]string
+
+	// firing has the conditions which are currently true, by rule and subject.
+	firing map[string]bool
+
+	round        uint64
+	lastProgress time.Time
+	// blockRound is the newest block checked by the transaction rules.
+	blockRound uint64
+}
+
+func newEngine(rules []Rule) engine {
+	return engine{
+		rules:  rules,
+		labels: make(map[types.Address]string),
+		firing: make(map[string]bool),
+	}
+}
+
+// name is the label of an address, or the address.
+func (e engine) name(addr types.Address) string {
+	if label := e.labels[addr]; label != "" {
+		return fmt.Sprintf("%s (%s)", label, addr)
+	}
+	return addr.String()
+}
+
+// set records the condition of a rule, an alert is returned when it changes.
+func (e *engine) set(rule int, subject string, firing bool, message string, now time.Time) []Alert {
+	k := fmt.Sprintf("%d/%s", rule, subject)
+	if e.firing[k] == firing {
+		return nil
+	}
+	if firing {
+		e.firing[k] = true
+	} else {
+		delete(e.firing, k)
+	}
+
+	state := Firing
+	if !firing {
+		state = Resolved
+	}
+	return []Alert{{
+		Rule:    e.rules[rule].title(),
+		Kind:    e.rules[rule].Kind,
+		Subject: subject,
+		State:   state,
+		Message: message,
+		Time:    now,
+	}}
+}
+
+// status checks the node progress, the round is also used by the key expiry
+// rules.
+func (e *engine) status(msg messages.StatusMsg, now time.Time) []Alert {
+	if e.lastProgress.IsZero() {
+		e.lastProgress = now
+	}
+	if msg.Error == nil && msg.Status.LastRound > e.round {
+		e.round = msg.Status.LastRound
+		e.lastProgress = now
+	}
+
+	var result []Alert
+	for i, r := range e.rules {
+		if r.Kind != nodeStalled {
+			continue
+		}
+		limit := time.Duration(r.Seconds) * time.Second
+		stalled := now.Sub(e.lastProgress)
+		if msg.Error == nil && time.Duration(msg.Status.TimeSinceLastRound) > stalled {
+			stalled = time.Duration(msg.Status.TimeSinceLastRound)
+		}
+		firing := stalled > limit
+		message := fmt.Sprintf("The node made progress again at round %d.", e.round)
+		switch {
+		case msg.Error == nil && msg.Status.StoppedAtUnsupportedRound:
+			firing = true
+			message = fmt.Sprintf("The node stopped at round %d, the protocol is not supported by this version.", e.round)
+		case firing && msg.Error != nil:
+			message = fmt.Sprintf("The node has not made progress for %s: %s", stalled.Round(time.Second), msg.Error)
+		case firing:
+			message = fmt.Sprintf("The node has not made progress for %s, the last round is %d.", stalled.Round(time.Second), e.round)
+		}
+		result = append(result, e.set(i, "node", firing, message, now)...)
+	}
+	return result
+}
+
+// accounts checks the balance, status and participation key rules.
+func (e *engine) accounts(msg messages.AccountStatusMsg, now time.Time) []Alert {
+	if msg.Err != nil {
+		return nil
+	}
+	var result []Alert
+	for i, r := range e.rules {
+		for addr, balances := range msg.Balances {
+			if !r.appliesTo(addr) {
+				continue
+			}
+			info, hasInfo := msg.Accounts[addr]
+			switch r.Kind {
+			case balanceBelow:
+				balance := balances[r.Asset]
+				firing := balance < r.Threshold
+				message := fmt.Sprintf("The balance of %s is %s again.", e.name(addr), formatAmount(r.Asset, balance))
+				if firing {
+					message = fmt.Sprintf("The balance of %s is %s, below %s.", e.name(addr),
+						formatAmount(r.Asset, balance), formatAmount(r.Asset, r.Threshold))
+				}
+				result = append(result, e.set(i, addr.String(), firing, message, now)...)
+			case accountOffline:
+				if !hasInfo {
+					continue
+				}
+				firing := info.Status != "Online"
+				message := fmt.Sprintf("%s is online again.", e.name(addr))
+				if firing {
+					message = fmt.Sprintf("%s is %s.", e.name(addr), info.Status)
+				}
+				result = append(result, e.set(i, addr.String(), firing, message, now)...)
+			case keyExpiry:
+				last := info.Participation.VoteLastValid
+				if !hasInfo || last == 0 || e.round == 0 {
+					continue
+				}
+				firing := last < e.round+r.Rounds
+				message := fmt.Sprintf("The participation key of %s is valid until round %d.", e.name(addr), last)
+				switch {
+				case firing && last <= e.round:
+					message = fmt.Sprintf("The participation key of %s expired at round %d.", e.name(addr), last)
+				case firing:
+					message = fmt.Sprintf("The participation key of %s expires in %d rounds, at round %d.", e.name(addr), last-e.round, last)
+				}
+				result = append(result, e.set(i, addr.String(), firing, message, now)...)
+			}
+		}
+	}
+	return result
+}
+
+// blocks checks the transaction rules, new blocks are also progress.
+func (e *engine) blocks(msg explorer.BlocksMsg, now time.Time) []Alert {
+	if msg.Err != nil {
+		return nil
+	}
+
+	// the first blocks are history, only newer transactions are reported.
+	if e.blockRound == 0 {
+		for _, blk := range msg.Blocks {
+			if blk.Round > e.blockRound {
+				e.blockRound = blk.Round
+			}
+		}
+		return nil
+	}
+
+	var result []Alert
+	for _, blk := range msg.Blocks {
+		if blk.Round <= e.blockRound {
+			continue
+		}
+		e.blockRound = blk.Round
+		if blk.Round > e.round {
+			e.round = blk.Round
+			e.lastProgress = now
+		}
+
+		for _, r := range e.rules {
+			if r.Kind != transaction {
+				continue
+			}
+			watched := make(map[types.Address]bool)
+			if !r.address.IsZero() {
+				watched[r.address] = true
+			} else {
+				for _, addr := range e.watched {
+					watched[addr] = true
+				}
+			}
+			for _, a := range explorer.FindActivity(blk, watched) {
+				// transactions without an amount only match a zero threshold.
+				if a.Asset != r.Asset || a.Amount < r.Threshold {
+					continue
+				}
+				for _, addr := range a.Watched {
+					result = append(result, Alert{
+						Rule:    r.title(),
+						Kind:    r.Kind,
+						Subject: addr.String(),
+						State:   Firing,
+						Message: fmt.Sprintf("%s %s in round %d: %s", e.name(addr), describe(a, addr), a.Round, a.TxID),
+						Time:    now,
+					})
+				}
+			}
+		}
+	}
+	return result
+}
+
+// describe summarizes a transaction from the point of view of an address.
+func describe(a explorer.Activity, addr types.Address) string {
+	switch {
+	case a.Type != types.PaymentTx && a.Type != types.AssetTransferTx:
+		return fmt.Sprintf("has a %s transaction", a.Type)
+	case a.Receiver == addr && a.Sender != addr:
+		return fmt.Sprintf("received %s", formatAmount(a.Asset, a.Amount))
+	default:
+		return fmt.Sprintf("sent %s", formatAmount(a.Asset, a.Amount))
+	}
+}
+
+func formatAmount(asset, amount uint64) string {
+	if asset == 0 {
+		return fmt.Sprintf("%f Algos", float64(amount)/1000000.0)
+	}
+	return fmt.Sprintf("%d of asset %d", amount, asset)
+}
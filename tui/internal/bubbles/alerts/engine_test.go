@@ -0,0 +1,154 @@
+package alerts
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/algorand/go-algorand-sdk/v2/client/v2/common/models"
+	"github.com/algorand/go-algorand-sdk/v2/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/winder/algorand-navigator/messages"
+)
+
+// states lists the state of each alert, in order.
+func states(alerts []Alert) []State {
+	result := make([]State, 0, len(alerts))
+	for _, a := range alerts {
+		result = append(result, a.State)
+	}
+	return result
+}
+
+func TestBalanceBelow(t *testing.T) {
+	addr := types.Address{1}
+	other := types.Address{2}
+
+	tests := []struct {
+		name string
+		rule Rule
+		// balances are the Algo balances of addr, one update each.
+		balances []uint64
+		expected [][]State
+	}{
+		{
+			name:     "above the threshold",
+			rule:     Rule{Kind: balanceBelow, Threshold: 1000},
+			balances: []uint64{2000},
+			expected: [][]State{{}},
+		},
+		{
+			name:     "at the threshold",
+			rule:     Rule{Kind: balanceBelow, Threshold: 1000},
+			balances: []uint64{1000},
+			expected: [][]State{{}},
+		},
+		{
+			name:     "fires once",
+			rule:     Rule{Kind: balanceBelow, Threshold: 1000},
+			balances: []uint64{999, 500},
+			expected: [][]State{{Firing}, {}},
+		},
+		{
+			name:     "resolved",
+			rule:     Rule{Kind: balanceBelow, Threshold: 1000},
+			balances: []uint64{500, 1500, 500},
+			expected: [][]State{{Firing}, {Resolved}, {Firing}},
+		},
+		{
+			name:     "other address",
+			rule:     Rule{Kind: balanceBelow, Threshold: 1000, address: other},
+			balances: []uint64{500},
+			expected: [][]State{{}},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			e := newEngine([]Rule{test.rule})
+			now := time.Now()
+			require.Len(t, test.expected, len(test.balances))
+			for i, balance := range test.balances {
+				alerts := e.accounts(messages.AccountStatusMsg{
+					Balances: map[types.Address]map[uint64]uint64{addr: {0: balance}},
+				}, now)
+				assert.Equal(t, test.expected[i], states(alerts), "update %d", i)
+				for _, a := range alerts {
+					assert.Equal(t, addr.String(), a.Subject)
+				}
+			}
+		})
+	}
+}
+
+func TestNodeStalled(t *testing.T) {
+	// status is the node status seen after some seconds.
+	type status struct {
+		seconds int
+		msg     messages.StatusMsg
+	}
+	round := func(r uint64) messages.StatusMsg {
+		return messages.StatusMsg{Status: models.NodeStatus{LastRound: r}}
+	}
+
+	tests := []struct {
+		name     string
+		updates  []status
+		expected [][]State
+	}{
+		{
+			name:     "progress",
+			updates:  []status{{0, round(1)}, {30, round(2)}, {80, round(3)}},
+			expected: [][]State{{}, {}, {}},
+		},
+		{
+			name:     "stalled",
+			updates:  []status{{0, round(1)}, {60, round(1)}, {61, round(1)}, {90, round(1)}},
+			expected: [][]State{{}, {}, {Firing}, {}},
+		},
+		{
+			name:     "resolved",
+			updates:  []status{{0, round(1)}, {61, round(1)}, {62, round(2)}},
+			expected: [][]State{{}, {Firing}, {Resolved}},
+		},
+		{
+			name:     "unreachable",
+			updates:  []status{{0, round(1)}, {61, messages.StatusMsg{Error: errors.New("connection refused")}}},
+			expected: [][]State{{}, {Firing}},
+		},
+		{
+			name: "time since last round",
+			updates: []status{{0, messages.StatusMsg{Status: models.NodeStatus{
+				LastRound:          1,
+				TimeSinceLastRound: uint64(2 * time.Minute),
+			}}}},
+			expected: [][]State{{Firing}},
+		},
+		{
+			name: "unsupported protocol",
+			updates: []status{{0, messages.StatusMsg{Status: models.NodeStatus{
+				LastRound:                 1,
+				StoppedAtUnsupportedRound: true,
+			}}}},
+			expected: [][]State{{Firing}},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			rule := Rule{Kind: nodeStalled}
+			require.NoError(t, rule.validate())
+			e := newEngine([]Rule{rule})
+			start := time.Now()
+			require.Len(t, test.expected, len(test.updates))
+			for i, u := range test.updates {
+				alerts := e.status(u.msg, start.Add(time.Duration(u.seconds)*time.Second))
+				assert.Equal(t, test.expected[i], states(alerts), "update %d", i)
+			}
+		})
+	}
+}
@@ -0,0 +1,176 @@
+package alerts
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// notifyTimeout limits how long a notifier may take.
+const notifyTimeout = 10 * time.Second
+
+// Notifier sends alerts somewhere.
+type Notifier interface {
+	// Name describes the notifier in error messages.
+	Name() string
+	Notify(ctx context.Context, alert Alert) error
+}
+
+// Notifier types.
+const (
+	terminalType = "terminal"
+	commandType  = "command"
+	webhookType  = "webhook"
+)
+
+// NotifierConfig describes a notifier in the alerts file.
+type NotifierConfig struct {
+	Type string `json:"type"`
+
+	// terminal options, both default to true.
+	Bell  *bool `json:"bell,omitempty"`
+	Toast *bool `json:"toast,omitempty"`
+
+	// Command is the program and arguments of a command hook.
+	Command []string `json:"command,omitempty"`
+
+	// URL and Headers are used by webhooks.
+	URL     string            `json:"url,omitempty"`
+	Headers map[string]string `json:"headers,omitempty"`
+}
+
+func enabled(option *bool) bool {
+	return option == nil || *option
+}
+
+// makeNotifiers creates the configured notifiers, the terminal notifier is
+// used when none are configured. toast is set when alerts should be displayed
+// in the navigator. The bell is written to the terminal of the program.
+func makeNotifiers(configs []NotifierConfig, bell io.Writer) (notifiers []Notifier, toast bool, err error) {
+	if len(configs) == 0 {
+		configs = []NotifierConfig{{Type: terminalType}}
+	}
+	for _, c := range configs {
+		switch c.Type {
+		case terminalType:
+			toast = toast || enabled(c.Toast)
+			if enabled(c.Bell) {
+				notifiers = append(notifiers, bellNotifier{out: bell})
+			}
+		case commandType:
+			if len(c.Command) == 0 {
+				return nil, false, fmt.Errorf("the command notifier needs a command")
+			}
+			notifiers = append(notifiers, commandNotifier{command: c.Command})
+		case webhookType:
+			if !strings.HasPrefix(c.URL, "http://") && !strings.HasPrefix(c.URL, "https://") {
+				return nil, false, fmt.Errorf("the webhook notifier needs an http or https url, not '%s'", c.URL)
+			}
+			notifiers = append(notifiers, webhookNotifier{
+				url:     c.URL,
+				headers: c.Headers,
+				client:  &http.Client{Timeout: notifyTimeout},
+			})
+		default:
+			return nil, false, fmt.Errorf("unknown notifier type '%s'", c.Type)
+		}
+	}
+	return notifiers, toast, nil
+}
+
+// bellNotifier rings the terminal bell. The toast is displayed by the Model.
+type bellNotifier struct {
+	out io.Writer
+}
+
+// Name is part of the Notifier interface.
+func (n bellNotifier) Name() string {
+	return "bell"
+}
+
+// Notify is part of the Notifier interface.
+func (n bellNotifier) Notify(_ context.Context, alert Alert) error {
+	if alert.State != Firing {
+		return nil
+	}
+	_, err := n.out.Write([]byte("\a"))
+	return err
+}
+
+// commandNotifier runs a command for each alert. The alert is passed as JSON
+// on stdin and in ALERT_* environment variables.
+type commandNotifier struct {
+	command []string
+}
+
+// Name is part of the Notifier interface.
+func (n commandNotifier) Name() string {
+	return n.command[0]
+}
+
+// Notify is part of the Notifier interface.
+func (n commandNotifier) Notify(ctx context.Context, alert Alert) error {
+	body, err := json.Marshal(alert)
+	if err != nil {
+		return err
+	}
+	cmd := exec.CommandContext(ctx, n.command[0], n.command[1:]...)
+	cmd.Stdin = bytes.NewReader(body)
+	cmd.Env = append(os.Environ(),
+		"ALERT_RULE="+alert.Rule,
+		"ALERT_KIND="+alert.Kind,
+		"ALERT_SUBJECT="+alert.Subject,
+		"ALERT_STATE="+string(alert.State),
+		"ALERT_MESSAGE="+alert.Message,
+		"ALERT_TIME="+alert.Time.Format(time.RFC3339))
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("%w: %s", err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// webhookNotifier posts each alert as JSON.
+type webhookNotifier struct {
+	url     string
+	headers map[string]string
+	client  *http.Client
+}
+
+// Name is part of the Notifier interface.
+func (n webhookNotifier) Name() string {
+	return n.url
+}
+
+// Notify is part of the Notifier interface.
+func (n webhookNotifier) Notify(ctx context.Context, alert Alert) error {
+	body, err := json.Marshal(alert)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range n.headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("HTTP %d: %s", resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package alerts
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func makeWebhook(t *testing.T, url string) Notifier {
+	notifiers, _, err := makeNotifiers([]NotifierConfig{{
+		Type:    webhookType,
+		URL:     url,
+		Headers: map[string]string{"Authorization": "Bearer secret"},
+	}}, nil)
+	require.NoError(t, err)
+	require.Len(t, notifiers, 1)
+	return notifiers[0]
+}
+
+func TestWebhookNotifier(t *testing.T) {
+	alert := Alert{
+		Rule:    "low balance",
+		Kind:    balanceBelow,
+		Subject: "node",
+		State:   Firing,
+		Message: "The balance is low.",
+		Time:    time.Date(2023, 7, 1, 12, 0, 0, 0, time.UTC),
+	}
+
+	var body map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	err := makeWebhook(t, server.URL).Notify(context.Background(), alert)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"rule":    "low balance",
+		"kind":    "balance_below",
+		"subject": "node",
+		"state":   "firing",
+		"message": "The balance is low.",
+		"time":    "2023-07-01T12:00:00Z",
+	}, body)
+}
+
+func TestWebhookNotifierStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "no alerts today", http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	err := makeWebhook(t, server.URL).Notify(context.Background(), Alert{State: Firing})
+	require.Error(t, err)
+	assert.Equal(t, "HTTP 500: no alerts today", err.Error())
+}
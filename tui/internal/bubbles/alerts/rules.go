@@ -0,0 +1,147 @@
+package alerts
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"time"
+
+	"github.com/algorand/go-algorand-sdk/v2/types"
+)
+
+// configFile is the file in the config dir with the alert rules and
+// notifiers.
+const configFile = "alerts.json"
+
+// Rule kinds.
+const (
+	// balanceBelow fires when the balance of an asset, or Algos, is below the
+	// threshold.
+	balanceBelow = "balance_below"
+	// accountOffline fires when an account is not online.
+	accountOffline = "account_offline"
+	// keyExpiry fires when the registered participation key of an account
+	// expires within the number of rounds.
+	keyExpiry = "key_expiry"
+	// nodeStalled fires when the node hasn't made progress for the number of
+	// seconds.
+	nodeStalled = "node_stalled"
+	// transaction fires for each transaction of an account with an amount of
+	// at least the threshold.
+	transaction = "transaction"
+)
+
+// Rule defaults.
+const (
+	// defaultExpiryRounds is about a week of rounds.
+	defaultExpiryRounds = 200_000
+	defaultStallSeconds = 60
+)
+
+// Rule describes when an alert fires. Account rules apply to every watched
+// account when the address is empty.
+type Rule struct {
+	Name    string `json:"name,omitempty"`
+	Kind    string `json:"kind"`
+	Address string `json:"address,omitempty"`
+	// Asset is the asset ID for balance and transaction rules, 0 for Algos.
+	Asset uint64 `json:"asset,omitempty"`
+	// Threshold is the balance, or the minimum transaction amount, in base
+	// units of the asset.
+	Threshold uint64 `json:"threshold,omitempty"`
+	Rounds    uint64 `json:"rounds,omitempty"`
+	Seconds   uint64 `json:"seconds,omitempty"`
+
+	address types.Address
+}
+
+// title is the rule name, or a description of the rule.
+func (r Rule) title() string {
+	if r.Name != "" {
+		return r.Name
+	}
+	return r.Kind
+}
+
+// appliesTo checks whether an account rule applies to an address.
+func (r Rule) appliesTo(addr types.Address) bool {
+	return r.address.IsZero() || r.address == addr
+}
+
+func (r *Rule) validate() error {
+	switch r.Kind {
+	case balanceBelow, accountOffline, transaction:
+	case keyExpiry:
+		if r.Rounds == 0 {
+			r.Rounds = defaultExpiryRounds
+		}
+	case nodeStalled:
+		if r.Seconds == 0 {
+			r.Seconds = defaultStallSeconds
+		}
+	default:
+		return fmt.Errorf("unknown rule kind '%s'", r.Kind)
+	}
+	if r.Address != "" {
+		addr, err := types.DecodeAddress(r.Address)
+		if err != nil {
+			return fmt.Errorf("rule %s has a bad address '%s': %w", r.title(), r.Address, err)
+		}
+		r.address = addr
+	}
+	return nil
+}
+
+// Config is the content of the alerts file.
+type Config struct {
+	Rules     []Rule           `json:"rules"`
+	Notifiers []NotifierConfig `json:"notifiers"`
+}
+
+// loadConfig reads the alerts file, a missing file has no rules.
+func loadConfig(configDir string) (Config, error) {
+	var cfg Config
+	data, err := os.ReadFile(path.Join(configDir, configFile))
+	if os.IsNotExist(err) {
+		return cfg, nil
+	}
+	if err != nil {
+		return cfg, err
+	}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return cfg, fmt.Errorf("%s is malformed: %w", configFile, err)
+	}
+	for i := range cfg.Rules {
+		if err := cfg.Rules[i].validate(); err != nil {
+			return cfg, fmt.Errorf("%s: %w", configFile, err)
+		}
+	}
+	return cfg, nil
+}
+
+// State is whether the condition of an alert started or stopped.
+type State string
+
+// Alert states.
+const (
+	Firing   State = "firing"
+	Resolved State = "resolved"
+)
+
+// Alert is a notification sent to the notifiers.
+type Alert struct {
+	Rule    string    `json:"rule"`
+	Kind    string    `json:"kind"`
+	Subject string    `json:"subject"`
+	State   State     `json:"state"`
+	Message string    `json:"message"`
+	Time    time.Time `json:"time"`
+}
+
+func (a Alert) String() string {
+	if a.State == Resolved {
+		return fmt.Sprintf("Resolved: %s", a.Message)
+	}
+	return a.Message
+}
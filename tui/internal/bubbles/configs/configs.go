@@ -1,14 +1,22 @@
 package configs
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
+	"sort"
 	"strings"
 
+	table "github.com/calyptia/go-bubble-table"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 
 	"github.com/winder/algorand-navigator/messages"
+	"github.com/winder/algorand-navigator/tui/internal/style"
+	"github.com/winder/algorand-navigator/tui/internal/util"
 )
 
 var (
@@ -25,80 +33,568 @@ var (
 	}()
 )
 
+// Hacked these in to workaround missing style options in table model
+var inactiveStyle = lipgloss.NewStyle()
+var activeStyle = inactiveStyle.Copy().Foreground(lipgloss.Color("#B083EA")).Bold(true)
+var warningStyle = inactiveStyle.Copy().Foreground(lipgloss.Color("#E3A322")).Bold(true)
+
+var configTableHeader = []string{"  KEY", "type", "value", "default"}
+
+// maxCellWidth keeps long values like DNSBootstrapID from pushing the other
+// columns off the screen.
+const maxCellWidth = 32
+
+type state int
+
+const (
+	listState state = iota
+	editState
+	reviewState
+	restartState
+	fileState
+	defaultsState
+)
+
+// configItem is a row of the config table.
+type configItem struct {
+	key     string
+	value   string
+	def     string
+	typ     string
+	set     bool
+	pending bool
+}
+
+// Render implements the Row interface to display a row of data.
+func (c configItem) Render(w io.Writer, model table.Model, index int) {
+	var cursor string
+	if index == model.Cursor() {
+		cursor = "> "
+	} else {
+		cursor = "  "
+	}
+
+	// * marks unsaved changes, keys from config.json are bold.
+	marker := " "
+	if c.pending {
+		marker = "*"
+	}
+	row := fmt.Sprintf("%s%s%s\t%s\t%s\t%s", cursor, marker, c.key, c.typ, c.value, c.def)
+	switch {
+	case index == model.Cursor():
+		row = activeStyle.Render(row)
+	case c.pending:
+		row = warningStyle.Render(row)
+	case c.set:
+		row = inactiveStyle.Copy().Bold(true).Render(row)
+	default:
+		row = inactiveStyle.Render(row)
+	}
+	fmt.Fprintln(w, row)
+}
+
 // Model representing the configs page.
 type Model struct {
 	requestor    *messages.Requestor
+	style        *style.Styles
 	heightMargin int
-	viewport     viewport.Model
+	width        int
+	height       int
+
+	state    state
+	table    table.Model
+	keys     []string
+	viewport viewport.Model
+	input    textinput.Model
+
+	// path and raw are the config.json file, file has its keys.
+	path    string
+	raw     []byte
+	file    map[string]json.RawMessage
+	fileErr error
+
+	// changes are the unsaved values, nil removes the key so that the
+	// default is used.
+	changes map[string]json.RawMessage
+	editKey string
+	editErr error
+
+	// result describes the last save or restart.
+	result string
+	err    error
 }
 
 // New creates a Model.
-func New(requestor *messages.Requestor, heightMargin int) Model {
+func New(styles *style.Styles, requestor *messages.Requestor, heightMargin int) Model {
+	t := table.New(configTableHeader, 0, 0)
+	t.KeyMap.Up.SetKeys(append(t.KeyMap.Up.Keys(), "k")...)
+	t.KeyMap.Down.SetKeys(append(t.KeyMap.Down.Keys(), "j")...)
+	t.Styles.Title = styles.StatusBoldText
+
 	m := Model{
 		requestor:    requestor,
+		style:        styles,
+		table:        t,
 		viewport:     viewport.New(0, 0),
+		input:        textinput.New(),
 		heightMargin: heightMargin,
+		changes:      make(map[string]json.RawMessage),
 	}
 	m.setSize(80, 20)
 	return m
 }
 
-// ConfigContent allows the update function to find its config content.
-type ConfigContent string
+// Init is part of the tea.Model interface.
+func (m Model) Init() tea.Cmd {
+	return m.requestor.GetConfigFileCmd()
+}
 
-func (m Model) getContent() tea.Cmd {
-	return func() tea.Msg {
-		return ConfigContent(m.requestor.GetConfigs())
-	}
+// CapturingInput is part of the util.InputCapturer interface.
+func (m Model) CapturingInput() bool {
+	return m.state == editState || m.state == reviewState || m.state == restartState
 }
 
-// Init is part of the tea.Model interface.
-func (m Model) Init() tea.Cmd {
-	return m.getContent()
+// UpdateKeys is part of the util.KeyUpdater interface.
+func (m Model) UpdateKeys(active bool) {
+	list := active && m.state == listState
+	util.AppKeys.ResetConfig.SetEnabled(list)
+	util.AppKeys.SaveConfig.SetEnabled(list && len(m.changes) > 0)
+	util.AppKeys.ConfigFile.SetEnabled(list)
+	util.AppKeys.ConfigDiff.SetEnabled(list)
 }
 
 func (m *Model) setSize(width, height int) {
+	m.width = width
+	m.height = height
+
 	headerHeight := lipgloss.Height(m.headerView())
 	footerHeight := lipgloss.Height(m.footerView())
-
 	m.viewport.Width = width
 	m.viewport.Height = height - m.heightMargin - headerHeight - footerHeight
+
+	tableHeight := height - m.heightMargin - m.style.Bottom.GetVerticalFrameSize() - lipgloss.Height(m.summaryView())
+	m.table.SetSize(width-m.style.Bottom.GetHorizontalFrameSize(), tableHeight)
+}
+
+// value returns the value of a key after the unsaved changes, and whether it
+// is set in the file.
+func (m Model) value(k string) (json.RawMessage, bool) {
+	if v, ok := m.changes[k]; ok {
+		return v, v != nil
+	}
+	v, ok := m.file[k]
+	return v, ok
+}
+
+// updateTable lists the schema keys followed by any unknown keys in the file.
+func (m *Model) updateTable() {
+	keys := schemaKeys()
+	var unknown []string
+	for k := range m.file {
+		if _, ok := schema[k]; !ok {
+			unknown = append(unknown, k)
+		}
+	}
+	sort.Strings(unknown)
+	keys = append(keys, unknown...)
+	m.keys = keys
+
+	rows := make([]table.Row, 0, len(keys))
+	for _, k := range keys {
+		v, set := m.value(k)
+		def := schema[k].Default
+		if !set {
+			v = def
+		}
+		_, pending := m.changes[k]
+		rows = append(rows, configItem{
+			key:     k,
+			value:   truncate(formatValue(k, v)),
+			def:     truncate(formatValue(k, def)),
+			typ:     fieldType(k),
+			set:     set,
+			pending: pending,
+		})
+	}
+	m.table.SetRows(rows)
+}
+
+// setFile parses config.json, values which don't match the schema are
+// reported but kept.
+func (m *Model) setFile(msg messages.ConfigFileMsg) {
+	m.path = msg.Path
+	m.raw = msg.Data
+	m.file = make(map[string]json.RawMessage)
+	m.fileErr = msg.Err
+	if msg.Err == nil && len(msg.Data) > 0 {
+		if err := json.Unmarshal(msg.Data, &m.file); err != nil {
+			m.fileErr = fmt.Errorf("config.json is malformed: %w", err)
+		}
+	}
+	if m.fileErr == nil {
+		keys := make([]string, 0, len(m.file))
+		for k := range m.file {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			if err := checkValue(k, m.file[k]); err != nil {
+				m.fileErr = err
+				break
+			}
+		}
+	}
+	m.updateTable()
+}
+
+// addsVersion is true when the saved file would have no Version. algod loads
+// such a file as version 0 and migrates the values which match the defaults of
+// older versions, so the latest version is written.
+func (m Model) addsVersion() bool {
+	if v, changed := m.changes[versionKey]; changed {
+		return v == nil
+	}
+	_, ok := m.file[versionKey]
+	return !ok
+}
+
+// newFile is config.json with the unsaved changes.
+func (m Model) newFile() ([]byte, error) {
+	result := make(map[string]json.RawMessage, len(m.file))
+	for k, v := range m.file {
+		result[k] = v
+	}
+	for k, v := range m.changes {
+		if v == nil {
+			delete(result, k)
+		} else {
+			result[k] = v
+		}
+	}
+	if m.addsVersion() {
+		result[versionKey] = json.RawMessage(fmt.Sprint(latestVersion))
+	}
+	data, err := json.MarshalIndent(result, "", "    ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// diffView describes the unsaved changes against the file and the defaults.
+func (m Model) diffView() string {
+	bold := m.style.StatusBoldText
+	keys := make([]string, 0, len(m.changes))
+	for k := range m.changes {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s\n\n", bold.Render(fmt.Sprintf("%d changes to %s", len(keys), m.path)))
+	if m.addsVersion() {
+		b.WriteString(bold.Render(versionKey))
+		b.WriteString("\n")
+		b.WriteString("  - (not set, version 0)\n")
+		fmt.Fprintf(&b, "  + %d\n", latestVersion)
+		b.WriteString("    added so that algod doesn't replace the values which match older defaults\n")
+	}
+	for _, k := range keys {
+		if k == versionKey && m.addsVersion() {
+			continue
+		}
+		def := formatValue(k, schema[k].Default)
+		current := "(not set, default)"
+		if v, ok := m.file[k]; ok {
+			current = formatValue(k, v)
+		}
+		updated := "(removed, default)"
+		if v := m.changes[k]; v != nil {
+			updated = formatValue(k, v)
+		}
+		b.WriteString(bold.Render(k))
+		b.WriteString("\n")
+		fmt.Fprintf(&b, "  - %s\n", current)
+		fmt.Fprintf(&b, "  + %s\n", updated)
+		fmt.Fprintf(&b, "    default %s\n", def)
+	}
+	if len(m.raw) > 0 {
+		b.WriteString("\nThe current file is kept as a backup next to config.json.")
+	}
+	return b.String()
 }
 
 // Update is part of the tea.Model interface.
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
-	var (
-		cmd  tea.Cmd
-		cmds []tea.Cmd
-	)
+	m, cmd := m.update(msg)
+	m.setSize(m.width, m.height)
+	return m, cmd
+}
 
+func (m Model) update(msg tea.Msg) (Model, tea.Cmd) {
 	switch msg := msg.(type) {
-	case ConfigContent:
-		// For some reason tabs make the viewport go crazy
-		//m.viewport.SetContent(string(msg))
-		m.viewport.SetContent(strings.ReplaceAll(string(msg), "\t", "    "))
+	case messages.ConfigFileMsg:
+		m.setFile(msg)
+		switch m.state {
+		case fileState:
+			m.viewport.SetContent(m.fileContent())
+		case defaultsState:
+			m.viewport.SetContent(m.diffDefaultsView())
+		}
+		return m, nil
+
+	case messages.ConfigSavedMsg:
+		m.err = msg.Err
+		if msg.Err != nil {
+			m.result = "Unable to save config.json"
+			return m, nil
+		}
+		m.changes = make(map[string]json.RawMessage)
+		m.result = fmt.Sprintf("Saved %s.", msg.Path)
+		if msg.Backup != "" {
+			m.result = fmt.Sprintf("Saved %s, the previous file is %s.", msg.Path, msg.Backup)
+		}
+		if m.requestor.CanUseGoal() {
+			m.state = restartState
+		}
+		return m, m.requestor.GetConfigFileCmd()
+
+	case messages.NodeRestartMsg:
+		m.err = msg.Err
+		m.result = "Restarted the node."
+		if msg.Err != nil {
+			m.result = "Unable to restart the node"
+		}
+		if msg.Output != "" {
+			m.result = fmt.Sprintf("%s %s", m.result, msg.Output)
+		}
+		return m, nil
 
 	case tea.WindowSizeMsg:
 		m.setSize(msg.Width, msg.Height)
+		return m, nil
+
+	case tea.KeyMsg:
+		return m.updateKeyMsg(msg)
+	}
+	return m, nil
+}
+
+func (m Model) updateKeyMsg(msg tea.KeyMsg) (Model, tea.Cmd) {
+	var cmd tea.Cmd
+	switch m.state {
+	case editState:
+		switch msg.Type {
+		case tea.KeyEsc:
+			m.state = listState
+			m.input.Blur()
+			return m, nil
+		case tea.KeyEnter:
+			value, err := parseValue(m.editKey, m.input.Value())
+			if err != nil {
+				m.editErr = err
+				return m, nil
+			}
+			m.setChange(m.editKey, value)
+			m.state = listState
+			m.input.Blur()
+			return m, nil
+		}
+		m.input, cmd = m.input.Update(msg)
+		return m, cmd
+
+	case reviewState:
+		switch {
+		case msg.Type == tea.KeyEsc || msg.String() == "n" || msg.String() == "N":
+			m.state = listState
+			return m, nil
+		case msg.String() != "y" && msg.String() != "Y":
+			// the diff may be longer than the screen.
+			m.viewport, cmd = m.viewport.Update(msg)
+			return m, cmd
+		}
+		m.state = listState
+		data, err := m.newFile()
+		if err != nil {
+			m.result, m.err = "Unable to save config.json", err
+			return m, nil
+		}
+		m.result, m.err = "Saving config.json...", nil
+		return m, m.requestor.SaveConfigCmd(data)
+
+	case restartState:
+		m.state = listState
+		if msg.String() != "y" && msg.String() != "Y" {
+			return m, nil
+		}
+		m.result, m.err = "Restarting the node...", nil
+		return m, m.requestor.RestartNodeCmd()
+
+	case fileState, defaultsState:
+		if key.Matches(msg, util.AppKeys.Back) ||
+			(m.state == fileState && key.Matches(msg, util.AppKeys.ConfigFile)) ||
+			(m.state == defaultsState && key.Matches(msg, util.AppKeys.ConfigDiff)) {
+			m.state = listState
+			return m, nil
+		}
+		m.viewport, cmd = m.viewport.Update(msg)
+		return m, cmd
+	}
+
+	item, selected := m.selected()
+	switch {
+	case key.Matches(msg, util.AppKeys.Forward):
+		if !selected || m.fileErr != nil {
+			return m, nil
+		}
+		m.state = editState
+		m.editKey = item.key
+		m.editErr = nil
+		value, set := m.value(item.key)
+		if !set {
+			value = schema[item.key].Default
+		}
+		m.input.SetValue(strings.Trim(formatInput(item.key, value), " "))
+		m.input.CursorEnd()
+		return m, m.input.Focus()
+	case key.Matches(msg, util.AppKeys.ResetConfig):
+		if selected && m.fileErr == nil {
+			m.setChange(item.key, nil)
+		}
+		return m, nil
+	case key.Matches(msg, util.AppKeys.SaveConfig):
+		if len(m.changes) > 0 {
+			m.state = reviewState
+			m.viewport.SetContent(m.diffView())
+			m.viewport.GotoTop()
+		}
+		return m, nil
+	case key.Matches(msg, util.AppKeys.ConfigFile):
+		m.state = fileState
+		m.viewport.SetContent(m.fileContent())
+		m.viewport.GotoTop()
+		return m, nil
+	case key.Matches(msg, util.AppKeys.ConfigDiff):
+		m.state = defaultsState
+		m.viewport.SetContent(m.diffDefaultsView())
+		m.viewport.GotoTop()
+		return m, nil
+	case key.Matches(msg, util.AppKeys.Back):
+		m.result, m.err = "", nil
+		return m, nil
+	}
+
+	m.table, cmd = m.table.Update(msg)
+	return m, cmd
+}
+
+// setChange records an unsaved value, changes back to the file value are
+// dropped.
+func (m *Model) setChange(name string, value json.RawMessage) {
+	current, inFile := m.file[name]
+	switch {
+	case value == nil && !inFile:
+		delete(m.changes, name)
+	case value != nil && inFile && equalValues(current, value):
+		delete(m.changes, name)
+	default:
+		m.changes[name] = value
+	}
+	m.result, m.err = "", nil
+	m.updateTable()
+}
+
+func truncate(s string) string {
+	if len(s) <= maxCellWidth {
+		return s
 	}
+	return s[:maxCellWidth-3] + "..."
+}
 
-	// Handle keyboard and mouse events in the viewport
-	m.viewport, cmd = m.viewport.Update(msg)
-	cmds = append(cmds, cmd)
+// formatInput is the value placed in the edit prompt, strings are unquoted.
+func formatInput(name string, raw json.RawMessage) string {
+	if fieldType(name) == "string" {
+		var s string
+		if json.Unmarshal(raw, &s) == nil {
+			return s
+		}
+	}
+	return string(raw)
+}
 
-	return m, tea.Batch(cmds...)
+func (m Model) selected() (configItem, bool) {
+	if len(m.keys) == 0 {
+		return configItem{}, false
+	}
+	item, ok := m.table.SelectedRow().(configItem)
+	return item, ok
+}
+
+// fileContent is config.json as it is on disk.
+func (m Model) fileContent() string {
+	switch {
+	case m.fileErr != nil && len(m.raw) == 0:
+		return m.fileErr.Error()
+	case len(m.raw) == 0:
+		return "config.json file not found, the node uses the defaults."
+	}
+	// For some reason tabs make the viewport go crazy
+	return strings.ReplaceAll(string(m.raw), "\t", "    ")
+}
+
+// summaryView is displayed above the config table.
+func (m Model) summaryView() string {
+	bold := m.style.StatusBoldText
+	var lines []string
+	switch {
+	case m.fileErr != nil:
+		lines = append(lines, warningStyle.Render(fmt.Sprintf("Error: %s", m.fileErr)), "Fix config.json before editing it here.")
+	case len(m.changes) > 0:
+		lines = append(lines, fmt.Sprintf("%d unsaved changes, press w to review and save them.", len(m.changes)))
+	default:
+		lines = append(lines, "Press enter to edit a key, d to reset it to the default, c to list the changes from the defaults and v to view config.json.")
+	}
+
+	switch m.state {
+	case editState:
+		lines = append(lines, "", bold.Render(fmt.Sprintf("%s (%s):", m.editKey, typeDescription(fieldType(m.editKey)))), m.input.View())
+		if m.editErr != nil {
+			lines = append(lines, warningStyle.Render("Error: "+m.editErr.Error()))
+		}
+		lines = append(lines, "enter: keep the change, esc: cancel", "")
+	case restartState:
+		lines = append(lines, "", m.result, bold.Render("algod reads config.json at start up. Restart the node with goal node restart? Press y to restart, any other key to skip."), "")
+		return strings.Join(lines, "\n")
+	}
+
+	if m.result != "" {
+		result := m.result
+		if m.err != nil {
+			result = warningStyle.Render(fmt.Sprintf("%s: %s", result, m.err))
+		}
+		lines = append(lines, strings.ReplaceAll(result, "\n", " "))
+	}
+	return strings.Join(lines, "\n")
 }
 
 // View is part of the tea.Model interface.
 func (m Model) View() string {
-
-	builder := strings.Builder{}
-	builder.WriteString(fmt.Sprintf("%s\n%s\n%s", m.headerView(), m.viewport.View(), m.footerView()))
-	return builder.String()
+	switch m.state {
+	case reviewState, fileState, defaultsState:
+		return fmt.Sprintf("%s\n%s\n%s", m.headerView(), m.viewport.View(), m.footerView())
+	}
+	return m.style.Bottom.Render(lipgloss.JoinVertical(0, m.summaryView(), m.table.View()))
 }
 
 func (m Model) headerView() string {
-	title := titleStyle.Render("Node configurations")
+	text := "Node configurations"
+	switch m.state {
+	case reviewState:
+		text = "Review changes, press y to save or n to cancel"
+	case defaultsState:
+		text = "Changes from the defaults"
+	}
+	title := titleStyle.Render(text)
 	line := strings.Repeat("─", max(0, m.viewport.Width-lipgloss.Width(title)))
 	return lipgloss.JoinHorizontal(lipgloss.Center, title, line)
 }
@@ -0,0 +1,124 @@
+package configs
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// change is a config.json value which differs from the default.
+type change struct {
+	key   string
+	value json.RawMessage
+	// def is nil for keys which don't exist in the config version.
+	def  json.RawMessage
+	note string
+}
+
+// fileVersion returns the Version of config.json, algod treats a missing
+// Version as version 0.
+func fileVersion(file map[string]json.RawMessage) (uint32, error) {
+	raw, ok := file[versionKey]
+	if !ok {
+		return 0, nil
+	}
+	var version uint32
+	if err := json.Unmarshal(raw, &version); err != nil {
+		return 0, fmt.Errorf("%s should be a positive integer, not %s", versionKey, raw)
+	}
+	return version, nil
+}
+
+// changedKeys compares config.json with the defaults of its version, and
+// returns the keys which are different, sorted.
+func changedKeys(file map[string]json.RawMessage, version uint32) []change {
+	keys := make([]string, 0, len(file))
+	for k := range file {
+		if k != versionKey {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	var changes []change
+	for _, k := range keys {
+		c := change{key: k, value: file[k]}
+		_, known := schema[k]
+		def, ok := defaultFor(k, version)
+		switch {
+		case !known:
+			c.note = "unknown key"
+		case !ok:
+			c.note = fmt.Sprintf("added in version %d", schema[k].Defaults[0].Version)
+		case equalValues(def, c.value):
+			continue
+		default:
+			c.def = def
+		}
+		changes = append(changes, c)
+	}
+	return changes
+}
+
+// diffDefaultsView lists the keys of config.json which are not the default for
+// its version.
+func (m Model) diffDefaultsView() string {
+	bold := m.style.StatusBoldText
+	switch {
+	case m.fileErr != nil:
+		return warningStyle.Render(fmt.Sprintf("Error: %s", m.fileErr))
+	case len(m.raw) == 0:
+		return fmt.Sprintf("config.json file not found, the node uses the defaults for version %d.", latestVersion)
+	}
+	version, err := fileVersion(m.file)
+	if err != nil {
+		return warningStyle.Render(fmt.Sprintf("Error: %s", err))
+	}
+
+	var b strings.Builder
+	if _, ok := m.file[versionKey]; ok {
+		fmt.Fprintf(&b, "config.json is version %d", version)
+	} else {
+		b.WriteString("config.json doesn't have a Version, algod uses version 0")
+	}
+	if version < latestVersion {
+		fmt.Fprintf(&b, ", the latest version is %d", latestVersion)
+	}
+	b.WriteString(".\n")
+	if len(m.changes) > 0 {
+		b.WriteString("The unsaved changes are not included.\n")
+	}
+	b.WriteString("\n")
+
+	changes := changedKeys(m.file, version)
+	if len(changes) == 0 {
+		b.WriteString("Every key is the default.")
+		return b.String()
+	}
+
+	rows := [][]string{{"KEY", fmt.Sprintf("DEFAULT (v%d)", version), "CONFIG.JSON"}}
+	for _, c := range changes {
+		def := formatValue(c.key, c.def)
+		if c.note != "" {
+			def = fmt.Sprintf("(%s)", c.note)
+		}
+		rows = append(rows, []string{c.key, def, formatValue(c.key, c.value)})
+	}
+	widths := make([]int, len(rows[0]))
+	for _, row := range rows {
+		for i, cell := range row {
+			widths[i] = max(widths[i], len(cell))
+		}
+	}
+	for i, row := range rows {
+		line := fmt.Sprintf("%-*s  %-*s  %s", widths[0], row[0], widths[1], row[1], row[2])
+		if i == 0 {
+			line = bold.Render(line)
+		}
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+	fmt.Fprintf(&b, "\n%d keys are not the default.", len(changes))
+	return b.String()
+}
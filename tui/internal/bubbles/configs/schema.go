@@ -0,0 +1,217 @@
+package configs
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// schema.json has the type of every algod config key, and its default for
+// each config version. The defaults are keyed by the version that introduced
+// them, a key doesn't exist before its first version.
+//
+//go:embed schema.json
+var schemaJSON []byte
+
+// versionKey is the config key holding the config version.
+const versionKey = "Version"
+
+// versionDefault is the default of a key starting from a config version.
+type versionDefault struct {
+	Version uint32
+	Value   json.RawMessage
+}
+
+// field describes a config key. The types are the Go types used by algod,
+// durations are stored in nanoseconds.
+type field struct {
+	Type string
+	// Default is the value for the latest config version.
+	Default json.RawMessage
+	// Defaults are sorted by version.
+	Defaults []versionDefault
+}
+
+var schema = func() map[string]field {
+	var raw map[string]struct {
+		Type     string                     `json:"type"`
+		Defaults map[string]json.RawMessage `json:"defaults"`
+	}
+	if err := json.Unmarshal(schemaJSON, &raw); err != nil {
+		panic(fmt.Sprintf("malformed config schema: %v", err))
+	}
+
+	fields := make(map[string]field, len(raw))
+	for k, r := range raw {
+		f := field{Type: r.Type}
+		for v, value := range r.Defaults {
+			version, err := strconv.ParseUint(v, 10, 32)
+			if err != nil {
+				panic(fmt.Sprintf("malformed config schema: %s has version '%s'", k, v))
+			}
+			f.Defaults = append(f.Defaults, versionDefault{Version: uint32(version), Value: value})
+		}
+		if len(f.Defaults) == 0 {
+			panic(fmt.Sprintf("malformed config schema: %s has no defaults", k))
+		}
+		sort.Slice(f.Defaults, func(i, j int) bool {
+			return f.Defaults[i].Version < f.Defaults[j].Version
+		})
+		f.Default = f.Defaults[len(f.Defaults)-1].Value
+		fields[k] = f
+	}
+	return fields
+}()
+
+// latestVersion is the newest config version in the schema.
+var latestVersion = func() uint32 {
+	var latest uint32
+	if err := json.Unmarshal(schema[versionKey].Default, &latest); err != nil {
+		panic(fmt.Sprintf("malformed config schema: %v", err))
+	}
+	return latest
+}()
+
+// defaultFor returns the default of a key for a config version, ok is false
+// when the key was added after that version.
+func defaultFor(key string, version uint32) (value json.RawMessage, ok bool) {
+	for _, d := range schema[key].Defaults {
+		if d.Version > version {
+			break
+		}
+		value, ok = d.Value, true
+	}
+	return value, ok
+}
+
+// unknownType is used for keys which are not in the schema.
+const unknownType = "unknown"
+
+func fieldType(key string) string {
+	if f, ok := schema[key]; ok {
+		return f.Type
+	}
+	return unknownType
+}
+
+// schemaKeys returns the keys in the schema, sorted.
+func schemaKeys() []string {
+	keys := make([]string, 0, len(schema))
+	for k := range schema {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// parseValue type-checks a value typed by the user and encodes it.
+func parseValue(key, input string) (json.RawMessage, error) {
+	input = strings.TrimSpace(input)
+	typ := fieldType(key)
+
+	var value interface{}
+	var err error
+	switch typ {
+	case "bool":
+		value, err = strconv.ParseBool(input)
+	case "int", "int64":
+		value, err = strconv.ParseInt(input, 10, 64)
+	case "uint32":
+		value, err = strconv.ParseUint(input, 10, 32)
+	case "uint", "uint64":
+		value, err = strconv.ParseUint(input, 10, 64)
+	case "duration":
+		// accept nanoseconds like config.json, or a duration like 60s.
+		var ns int64
+		ns, err = strconv.ParseInt(input, 10, 64)
+		if err != nil {
+			var d time.Duration
+			d, err = time.ParseDuration(input)
+			ns = int64(d)
+		}
+		value = ns
+	case "string":
+		value = input
+	case "map":
+		var m map[string]interface{}
+		err = json.Unmarshal([]byte(input), &m)
+		value = m
+	default:
+		if !json.Valid([]byte(input)) {
+			return nil, fmt.Errorf("%s is not in the schema, the value must be JSON", key)
+		}
+		return json.RawMessage(input), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("'%s' is not a valid %s", input, typeDescription(typ))
+	}
+	data, err := json.Marshal(value)
+	return data, err
+}
+
+func typeDescription(typ string) string {
+	switch typ {
+	case "bool":
+		return "boolean, use true or false"
+	case "int", "int64":
+		return "integer"
+	case "uint", "uint32", "uint64":
+		return "positive integer (" + typ + ")"
+	case "duration":
+		return "duration, use nanoseconds or a value like 30s"
+	case "map":
+		return "JSON object"
+	}
+	return typ
+}
+
+// checkValue checks a value from config.json against the schema.
+func checkValue(key string, raw json.RawMessage) error {
+	switch fieldType(key) {
+	case unknownType:
+		return nil
+	case "string":
+		var s string
+		if json.Unmarshal(raw, &s) != nil {
+			return fmt.Errorf("%s should be a string, not %s", key, raw)
+		}
+		return nil
+	}
+	if _, err := parseValue(key, string(raw)); err != nil {
+		return fmt.Errorf("%s: %w", key, err)
+	}
+	return nil
+}
+
+// formatValue displays a JSON value, durations are also shown in a readable
+// form.
+func formatValue(key string, raw json.RawMessage) string {
+	if raw == nil {
+		return "-"
+	}
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, raw); err != nil {
+		return string(raw)
+	}
+	text := compact.String()
+	if fieldType(key) == "duration" {
+		if ns, err := strconv.ParseInt(text, 10, 64); err == nil {
+			text = fmt.Sprintf("%s (%s)", text, time.Duration(ns))
+		}
+	}
+	return text
+}
+
+// equalValues compares JSON values regardless of formatting.
+func equalValues(a, b json.RawMessage) bool {
+	var ca, cb bytes.Buffer
+	if json.Compact(&ca, a) != nil || json.Compact(&cb, b) != nil {
+		return bytes.Equal(a, b)
+	}
+	return bytes.Equal(ca.Bytes(), cb.Bytes())
+}
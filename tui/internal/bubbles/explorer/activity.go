@@ -0,0 +1,182 @@
+package explorer
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/algorand/go-algorand-sdk/v2/types"
+)
+
+// Activity is a transaction, or an inner transaction, which references a
+// watched address.
+type Activity struct {
+	Round uint64
+	Time  time.Time
+
+	// TxID is the ID of the top level transaction.
+	TxID  string
+	Intra int
+	// Path is the position of an inner transaction, see ShowTxnMsg.
+	Path []int
+
+	Type     types.TxType
+	Sender   types.Address
+	Receiver types.Address
+	// Asset is 0 for payments.
+	Asset  uint64
+	Amount uint64
+
+	// Watched are the watched addresses referenced by the transaction.
+	Watched []types.Address
+}
+
+// InnerLabel describes the position of an inner transaction, like "inner 0.2".
+func (a Activity) InnerLabel() string {
+	return transactionItem{path: a.Path}.innerLabel()
+}
+
+func makeActivity(blk BlockItem, item transactionItem, watched []types.Address) Activity {
+	txn := item.Txn
+	activity := Activity{
+		Round:   blk.Round,
+		Time:    time.Unix(blk.Block.Block.TimeStamp, 0),
+		TxID:    item.id,
+		Intra:   item.intra,
+		Path:    item.path,
+		Type:    txn.Type,
+		Sender:  txn.Sender,
+		Watched: watched,
+	}
+	switch txn.Type {
+	case types.PaymentTx:
+		activity.Receiver = txn.Receiver
+		activity.Amount = uint64(txn.Amount)
+	case types.AssetTransferTx:
+		activity.Receiver = txn.AssetReceiver
+		activity.Asset = uint64(txn.XferAsset)
+		activity.Amount = txn.AssetAmount
+	}
+	return activity
+}
+
+// watchedAddresses returns the watched addresses referenced by a transaction.
+func watchedAddresses(stxn *types.SignedTxnWithAD, watched map[types.Address]bool) []types.Address {
+	var result []types.Address
+	for _, addr := range addresses(stxn) {
+		if !watched[addr] {
+			continue
+		}
+		duplicate := false
+		for _, r := range result {
+			duplicate = duplicate || r == addr
+		}
+		if !duplicate {
+			result = append(result, addr)
+		}
+	}
+	return result
+}
+
+func findInnerActivity(blk BlockItem, parent transactionItem, watched map[types.Address]bool, result *[]Activity) {
+	for _, item := range parent.children() {
+		if found := watchedAddresses(&item.SignedTxnWithAD, watched); len(found) > 0 {
+			*result = append(*result, makeActivity(blk, item, found))
+		}
+		findInnerActivity(blk, item, watched, result)
+	}
+}
+
+// FindActivity returns the transactions in a block which reference any of the
+// watched addresses, including inner transactions, in block order.
+func FindActivity(blk BlockItem, watched map[types.Address]bool) []Activity {
+	var result []Activity
+	if len(watched) == 0 {
+		return result
+	}
+	header := blk.Block.Block.BlockHeader
+	for intra := range blk.Block.Block.Payset {
+		stib := &blk.Block.Block.Payset[intra]
+		found := watchedAddresses(&stib.SignedTxnWithAD, watched)
+
+		// computing the ID is expensive, only do it when needed.
+		if len(found) == 0 && len(stib.EvalDelta.InnerTxns) == 0 {
+			continue
+		}
+		item := makeTransactionItem(header, intra, stib)
+		if len(found) > 0 {
+			result = append(result, makeActivity(blk, item, found))
+		}
+		findInnerActivity(blk, item, watched, &result)
+	}
+	return result
+}
+
+// ShowTxnMsg opens a transaction in the explorer, the block is fetched when it
+// isn't cached.
+type ShowTxnMsg struct {
+	Round uint64
+	Intra int
+	// Path is the position of an inner transaction below the top level
+	// transaction, it is empty for top level transactions.
+	Path []int
+}
+
+// showTxn opens the transaction when its block is cached. Leaving the
+// transaction returns to the block's transactions.
+func (m *Model) showTxn(msg ShowTxnMsg) bool {
+	var blk BlockItem
+	found := false
+	for _, b := range m.blocks {
+		if b.Round == msg.Round {
+			blk, found = b, true
+			break
+		}
+	}
+	if !found {
+		return false
+	}
+
+	m.openPayset(blk)
+	if msg.Intra >= len(m.transactions) {
+		m.pageErr = fmt.Errorf("round %d has no transaction %d", msg.Round, msg.Intra)
+		m.state = blockState
+		m.initBlocks()
+		return true
+	}
+	item := m.transactions[msg.Intra]
+	for _, i := range msg.Path {
+		children := item.children()
+		if i >= len(children) {
+			m.pageErr = fmt.Errorf("round %d has no inner transaction %s", msg.Round, transactionItem{path: msg.Path}.innerLabel())
+			m.state = blockState
+			m.initBlocks()
+			return true
+		}
+		// expand the parents so the transaction is visible in the block.
+		m.expanded[item.key()] = true
+		item = children[i]
+	}
+	m.updateTxnTable()
+
+	m.state = txnState
+	m.txnReturn = paysetState
+	m.initTransaction(item)
+	return true
+}
+
+// showTxnCmd fetches the page of blocks around the transaction and opens it.
+func (m *Model) showTxnCmd(msg ShowTxnMsg) tea.Cmd {
+	if msg.Round > m.latestRound {
+		m.pageErr = fmt.Errorf("round %d is not available, the latest round is %d", msg.Round, m.latestRound)
+		return nil
+	}
+	m.loadingPage = true
+	jump := m.jumpToRoundCmd(msg.Round)
+	return func() tea.Msg {
+		page := jump().(pageBlocksMsg)
+		page.show = &msg
+		return page
+	}
+}
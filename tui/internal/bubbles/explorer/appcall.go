@@ -0,0 +1,198 @@
+package explorer
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/algorand/go-algorand-sdk/v2/types"
+)
+
+// arc4ReturnPrefix is logged before the return value of an ARC-4 method.
+var arc4ReturnPrefix = []byte{0x15, 0x1f, 0x7c, 0x75}
+
+var onCompletionNames = map[types.OnCompletion]string{
+	types.NoOpOC:              "NoOp",
+	types.OptInOC:             "OptIn",
+	types.CloseOutOC:          "CloseOut",
+	types.ClearStateOC:        "ClearState",
+	types.UpdateApplicationOC: "UpdateApplication",
+	types.DeleteApplicationOC: "DeleteApplication",
+}
+
+func isPrintable(b []byte) bool {
+	if len(b) == 0 || !utf8.Valid(b) {
+		return false
+	}
+	for _, r := range string(b) {
+		if !unicode.IsPrint(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// formatBytes displays printable values as strings and everything else as hex.
+func formatBytes(b []byte) string {
+	if isPrintable(b) {
+		return strconv.Quote(string(b))
+	}
+	return "0x" + hex.EncodeToString(b)
+}
+
+// decodeArg lists the plausible interpretations of an application argument.
+func decodeArg(arg []byte) string {
+	decoded := []string{formatBytes(arg)}
+	switch len(arg) {
+	case 4:
+		decoded = append(decoded, "ARC-4 method selector")
+	case 8:
+		decoded = append(decoded, fmt.Sprintf("uint64 %d", binary.BigEndian.Uint64(arg)))
+	case len(types.Address{}):
+		var addr types.Address
+		copy(addr[:], arg)
+		decoded = append(decoded, fmt.Sprintf("address %s", addr))
+	}
+	return strings.Join(decoded, ", ")
+}
+
+// decodeLog detects ARC-4 return values and possible ARC-28 events. An ARC-28
+// event starts with a 4 byte selector, without the application's ABI it can't
+// be told apart from other binary logs.
+func decodeLog(log []byte) string {
+	switch {
+	case bytes.HasPrefix(log, arc4ReturnPrefix):
+		return fmt.Sprintf("ARC-4 return value %s", formatBytes(log[len(arc4ReturnPrefix):]))
+	case isPrintable(log):
+		return strconv.Quote(string(log))
+	case len(log) >= 4:
+		return fmt.Sprintf("0x%s, possible ARC-28 event (selector 0x%s)", hex.EncodeToString(log), hex.EncodeToString(log[:4]))
+	}
+	return formatBytes(log)
+}
+
+func formatValueDelta(vd types.ValueDelta) string {
+	switch vd.Action {
+	case types.SetBytesAction:
+		return fmt.Sprintf("set bytes %s", formatBytes([]byte(vd.Bytes)))
+	case types.SetUintAction:
+		return fmt.Sprintf("set uint %d", vd.Uint)
+	case types.DeleteAction:
+		return "delete"
+	}
+	return fmt.Sprintf("unknown action %d", vd.Action)
+}
+
+func writeStateDelta(b *strings.Builder, prefix string, delta types.StateDelta) {
+	keys := make([]string, 0, len(delta))
+	for k := range delta {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		fmt.Fprintf(b, "%s%s: %s\n", prefix, formatBytes([]byte(k)), formatValueDelta(delta[k]))
+	}
+}
+
+// localDeltaAccount resolves the account index used by local state deltas.
+func localDeltaAccount(txn *types.SignedTxnWithAD, index uint64) string {
+	accounts := append([]types.Address{txn.Txn.Sender}, txn.Txn.Accounts...)
+	accounts = append(accounts, txn.EvalDelta.SharedAccts...)
+	if index < uint64(len(accounts)) {
+		return accounts[index].String()
+	}
+	return fmt.Sprintf("account %d", index)
+}
+
+func joinUints[T ~uint64](ids []T) string {
+	parts := make([]string, 0, len(ids))
+	for _, id := range ids {
+		parts = append(parts, strconv.FormatUint(uint64(id), 10))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// appCallDetails renders a structured view of an application call.
+func appCallDetails(txn *types.SignedTxnWithAD, heading lipgloss.Style) string {
+	var b strings.Builder
+	call := txn.Txn.ApplicationCallTxnFields
+
+	b.WriteString(heading.Render("Application call"))
+	b.WriteString("\n")
+	oc, ok := onCompletionNames[call.OnCompletion]
+	if !ok {
+		oc = fmt.Sprintf("unknown (%d)", call.OnCompletion)
+	}
+	fmt.Fprintf(&b, "  On completion:  %s\n", oc)
+	if call.ApplicationID == 0 {
+		fmt.Fprintf(&b, "  Application ID: %d (created)\n", txn.ApplyData.ApplicationID)
+	} else {
+		fmt.Fprintf(&b, "  Application ID: %d\n", call.ApplicationID)
+	}
+	if len(call.ForeignApps) > 0 {
+		fmt.Fprintf(&b, "  Foreign apps:   %s\n", joinUints(call.ForeignApps))
+	}
+	if len(call.ForeignAssets) > 0 {
+		fmt.Fprintf(&b, "  Foreign assets: %s\n", joinUints(call.ForeignAssets))
+	}
+	for i, acct := range call.Accounts {
+		fmt.Fprintf(&b, "  Account [%d]:    %s\n", i+1, acct)
+	}
+	for _, box := range call.BoxReferences {
+		app := uint64(call.ApplicationID)
+		if box.ForeignAppIdx > 0 && box.ForeignAppIdx <= uint64(len(call.ForeignApps)) {
+			app = uint64(call.ForeignApps[box.ForeignAppIdx-1])
+		}
+		fmt.Fprintf(&b, "  Box:            app %d, %s\n", app, formatBytes(box.Name))
+	}
+
+	if len(call.ApplicationArgs) > 0 {
+		b.WriteString("\n")
+		b.WriteString(heading.Render("Arguments"))
+		b.WriteString("\n")
+		for i, arg := range call.ApplicationArgs {
+			fmt.Fprintf(&b, "  [%d] %s\n", i, decodeArg(arg))
+		}
+	}
+
+	if len(txn.EvalDelta.Logs) > 0 {
+		b.WriteString("\n")
+		b.WriteString(heading.Render("Logs"))
+		b.WriteString("\n")
+		for i, log := range txn.EvalDelta.Logs {
+			fmt.Fprintf(&b, "  [%d] %s\n", i, decodeLog([]byte(log)))
+		}
+	}
+
+	if len(txn.EvalDelta.GlobalDelta) > 0 {
+		b.WriteString("\n")
+		b.WriteString(heading.Render("Global state delta"))
+		b.WriteString("\n")
+		writeStateDelta(&b, "  ", txn.EvalDelta.GlobalDelta)
+	}
+
+	if len(txn.EvalDelta.LocalDeltas) > 0 {
+		b.WriteString("\n")
+		b.WriteString(heading.Render("Local state delta"))
+		b.WriteString("\n")
+		indexes := make([]uint64, 0, len(txn.EvalDelta.LocalDeltas))
+		for i := range txn.EvalDelta.LocalDeltas {
+			indexes = append(indexes, i)
+		}
+		sort.Slice(indexes, func(i, j int) bool { return indexes[i] < indexes[j] })
+		for _, i := range indexes {
+			fmt.Fprintf(&b, "  %s\n", localDeltaAccount(txn, i))
+			writeStateDelta(&b, "    ", txn.EvalDelta.LocalDeltas[i])
+		}
+	}
+
+	return b.String()
+}
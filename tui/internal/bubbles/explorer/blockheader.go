@@ -0,0 +1,211 @@
+package explorer
+
+import (
+	"encoding/base64"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/reflow/indent"
+
+	"github.com/algorand/go-algorand-sdk/v2/types"
+)
+
+// proposalValue identifies the block which a certificate agrees on.
+type proposalValue struct {
+	OriginalPeriod   uint64        `codec:"oper"`
+	OriginalProposer types.Address `codec:"oprop"`
+	BlockDigest      types.Digest  `codec:"dig"`
+	EncodingDigest   types.Digest  `codec:"encdig"`
+}
+
+// certVote is a vote in a certificate, the credential and signature are not
+// decoded.
+type certVote struct {
+	Sender types.Address `codec:"snd"`
+}
+
+// certificate is the agreement bundle which commits a block.
+type certificate struct {
+	Round             uint64        `codec:"rnd"`
+	Period            uint64        `codec:"per"`
+	Step              uint64        `codec:"step"`
+	Proposal          proposalValue `codec:"prop"`
+	Votes             []certVote    `codec:"vote"`
+	EquivocationVotes []certVote    `codec:"eqv"`
+}
+
+// certificateResponse picks the certificate out of a raw block response.
+type certificateResponse struct {
+	Cert *certificate `codec:"cert"`
+}
+
+// decodeCertificate returns nil when the response has no certificate.
+func decodeCertificate(raw []byte) (*certificate, error) {
+	var resp certificateResponse
+	if err := lenientDecode(raw, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Cert, nil
+}
+
+// proposerAddress returns the original proposer, ok is false when it is unknown.
+func (c *certificate) proposerAddress() (proposer types.Address, ok bool) {
+	if c == nil || c.Proposal.OriginalProposer.IsZero() {
+		return types.Address{}, false
+	}
+	return c.Proposal.OriginalProposer, true
+}
+
+func (c *certificate) proposer() string {
+	proposer, ok := c.proposerAddress()
+	if !ok {
+		return "<unknown>"
+	}
+	return proposer.String()
+}
+
+// stepName follows the agreement protocol step numbering.
+func stepName(step uint64) string {
+	switch {
+	case step == 0:
+		return "propose"
+	case step == 1:
+		return "soft"
+	case step == 2:
+		return "cert"
+	case step == 253:
+		return "late"
+	case step == 254:
+		return "redo"
+	case step == 255:
+		return "down"
+	}
+	return fmt.Sprintf("next %d", step-3)
+}
+
+func b64(b []byte) string {
+	return base64.StdEncoding.EncodeToString(b)
+}
+
+func writeField(b *strings.Builder, name string, value interface{}) {
+	fmt.Fprintf(b, "  %-22s %v\n", name+":", value)
+}
+
+// blockHeaderDetails renders the block header and certificate.
+func blockHeaderDetails(blk BlockItem, heading lipgloss.Style) string {
+	var b strings.Builder
+	header := blk.Block.Block.BlockHeader
+
+	b.WriteString(heading.Render("Block"))
+	b.WriteString("\n")
+	writeField(&b, "Round", header.Round)
+	writeField(&b, "Timestamp", fmt.Sprintf("%s (%d)", time.Unix(header.TimeStamp, 0).Format("2006-01-02 15:04:05 MST"), header.TimeStamp))
+	writeField(&b, "Previous hash", b64(header.Branch[:]))
+	writeField(&b, "Seed", b64(header.Seed[:]))
+	writeField(&b, "Genesis ID", header.GenesisID)
+	writeField(&b, "Genesis hash", b64(header.GenesisHash[:]))
+	writeField(&b, "Txn root", b64(header.NativeSha512_256Commitment[:]))
+	writeField(&b, "Txn root (SHA-256)", b64(header.Sha256Commitment[:]))
+	writeField(&b, "Txn counter", header.TxnCounter)
+
+	b.WriteString("\n")
+	b.WriteString(heading.Render("Rewards"))
+	b.WriteString("\n")
+	writeField(&b, "Fee sink", header.FeeSink)
+	writeField(&b, "Rewards pool", header.RewardsPool)
+	writeField(&b, "Rewards level", header.RewardsLevel)
+	writeField(&b, "Rewards rate", header.RewardsRate)
+	writeField(&b, "Rewards residue", header.RewardsResidue)
+	writeField(&b, "Recalculation round", header.RewardsRecalculationRound)
+
+	b.WriteString("\n")
+	b.WriteString(heading.Render("Protocol upgrade"))
+	b.WriteString("\n")
+	writeField(&b, "Current protocol", header.CurrentProtocol)
+	if header.NextProtocol != "" {
+		writeField(&b, "Next protocol", header.NextProtocol)
+		writeField(&b, "Approvals", header.NextProtocolApprovals)
+		writeField(&b, "Vote before", header.NextProtocolVoteBefore)
+		writeField(&b, "Switch on", header.NextProtocolSwitchOn)
+	}
+	if header.UpgradePropose != "" {
+		writeField(&b, "Proposed upgrade", header.UpgradePropose)
+		writeField(&b, "Upgrade delay", header.UpgradeDelay)
+	}
+	writeField(&b, "Approves upgrade", header.UpgradeApprove)
+
+	if len(header.StateProofTracking) > 0 {
+		b.WriteString("\n")
+		b.WriteString(heading.Render("State proof tracking"))
+		b.WriteString("\n")
+		spTypes := make([]types.StateProofType, 0, len(header.StateProofTracking))
+		for t := range header.StateProofTracking {
+			spTypes = append(spTypes, t)
+		}
+		sort.Slice(spTypes, func(i, j int) bool { return spTypes[i] < spTypes[j] })
+		for _, t := range spTypes {
+			sp := header.StateProofTracking[t]
+			fmt.Fprintf(&b, "  Type %d\n", t)
+			if !sp.StateProofVotersCommitment.IsEmpty() {
+				writeField(&b, "  Voters commitment", b64(sp.StateProofVotersCommitment))
+			}
+			writeField(&b, "  Online total weight", fmt.Sprintf("%f", sp.StateProofOnlineTotalWeight.ToAlgos()))
+			writeField(&b, "  Next round", sp.StateProofNextRound)
+		}
+	}
+
+	if len(header.ExpiredParticipationAccounts) > 0 {
+		b.WriteString("\n")
+		b.WriteString(heading.Render("Expired participation accounts"))
+		b.WriteString("\n")
+		for _, addr := range header.ExpiredParticipationAccounts {
+			fmt.Fprintf(&b, "  %s\n", addr)
+		}
+	}
+
+	b.WriteString("\n")
+	b.WriteString(heading.Render("Certificate"))
+	b.WriteString("\n")
+	cert := blk.cert
+	if cert == nil {
+		b.WriteString("  not available\n")
+		return b.String()
+	}
+	writeField(&b, "Proposer", cert.proposer())
+	writeField(&b, "Period", cert.Period)
+	writeField(&b, "Step", fmt.Sprintf("%s (%d)", stepName(cert.Step), cert.Step))
+	writeField(&b, "Original period", cert.Proposal.OriginalPeriod)
+	writeField(&b, "Block digest", b64(cert.Proposal.BlockDigest[:]))
+	writeField(&b, "Encoding digest", b64(cert.Proposal.EncodingDigest[:]))
+	writeField(&b, "Votes", len(cert.Votes))
+	writeField(&b, "Equivocation votes", len(cert.EquivocationVotes))
+
+	if len(cert.Votes) > 0 {
+		b.WriteString("\n")
+		b.WriteString(heading.Render("Voters"))
+		b.WriteString("\n")
+		for _, v := range cert.Votes {
+			fmt.Fprintf(&b, "  %s\n", v.Sender)
+		}
+	}
+	if len(cert.EquivocationVotes) > 0 {
+		b.WriteString("\n")
+		b.WriteString(heading.Render("Equivocating voters"))
+		b.WriteString("\n")
+		for _, v := range cert.EquivocationVotes {
+			fmt.Fprintf(&b, "  %s\n", v.Sender)
+		}
+	}
+
+	return b.String()
+}
+
+// initBlockHeader displays the header of the selected block.
+func (m *Model) initBlockHeader(blk BlockItem) {
+	m.viewTitle = fmt.Sprintf("Block: %d", blk.Round)
+	m.txnView.YOffset = 0
+	m.txnView.SetContent(indent.String(blockHeaderDetails(blk, m.style.StatusBoldText), 6))
+}
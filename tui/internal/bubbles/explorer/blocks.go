@@ -3,19 +3,49 @@ package explorer
 import (
 	"fmt"
 	"io"
+	"sort"
 	"strconv"
 
 	"github.com/algorand/go-algorand-sdk/v2/client/v2/common/models"
 	"github.com/algorand/go-algorand-sdk/v2/types"
 	table "github.com/calyptia/go-bubble-table"
+	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+
+	"github.com/winder/algorand-navigator/tui/internal/util"
 )
 
 // BlockItem is used by the list bubble.
 type BlockItem struct {
 	Round uint64
 	Block models.BlockResponse
+
+	// cert is the typed certificate, nil when it isn't available.
+	cert *certificate
+
+	// row is the rendered summary, computed once since blocks never change.
+	row string
+}
+
+func makeBlockItem(round uint64, raw []byte) (BlockItem, error) {
+	item := BlockItem{Round: round}
+	err := lenientDecode(raw, &item.Block)
+	if err != nil {
+		return item, err
+	}
+	item.cert, err = decodeCertificate(raw)
+	if err != nil {
+		return item, err
+	}
+	item.row = computeBlockRow(item)
+	return item, nil
+}
+
+// Proposer returns the address which proposed the block, ok is false when the
+// certificate isn't available.
+func (i BlockItem) Proposer() (proposer types.Address, ok bool) {
+	return i.cert.proposerAddress()
 }
 
 // Hacked these in to workaround missing style options in table model
@@ -25,35 +55,6 @@ var keyStyle = inactiveStyle.Copy().Width(10).Foreground(lipgloss.Color("#A3A322
 
 var blockTableHeader = []string{"  ROUND", "Txns", "Pay", "[Sum λ]", "Axfer", "Acfg", "Afrz", "[Unique]", "Appl", "[Unique]", "Proposer"}
 
-func proposer(cert *map[string]interface{}) string {
-	if cert == nil {
-		return "<unknown>"
-	}
-
-	// There must be a better way than this...
-	prop, ok := (*cert)["prop"]
-	if ok {
-		switch p := prop.(type) {
-		case map[interface{}]interface{}:
-			for k, v := range p {
-				switch c := k.(type) {
-				case string:
-					if c == "oprop" {
-						switch c2 := v.(type) {
-						case []byte:
-							var addr types.Address
-							copy(addr[:], c2)
-							return addr.String()
-						}
-					}
-				}
-			}
-		}
-	}
-
-	return "<unknown>"
-}
-
 func computeBlockRow(b BlockItem) string {
 	block := b.Block.Block
 
@@ -113,7 +114,7 @@ func computeBlockRow(b BlockItem) string {
 		len(assets),
 		typeCount[types.ApplicationCallTx],
 		len(apps),
-		proposer(b.Block.Cert))
+		b.cert.proposer())
 }
 
 // Render implements the Row interface to display a row of data.
@@ -128,7 +129,7 @@ func (i BlockItem) Render(w io.Writer, model table.Model, index int) {
 	cursor = activeStyle.Render(cursor)
 	//round := keyStyle.Render(strconv.FormatUint(uint64(i.Block.Round()), 10))
 	round := keyStyle.Render(strconv.FormatUint(i.Round, 10))
-	rest := computeBlockRow(i)
+	rest := i.row
 	if index == model.Cursor() {
 		rest = activeStyle.Render(rest)
 	} else {
@@ -150,6 +151,176 @@ func (m *Model) updateBlockTable() {
 	m.table.SetRows(rows)
 }
 
+// addBlocks merges items into the cache. The cache is kept as a contiguous
+// range of rounds, newest first, which contains the selected round and holds
+// at most maxBlocks blocks.
+func (m *Model) addBlocks(items []BlockItem, selected uint64) {
+	byRound := make(map[uint64]BlockItem, len(m.blocks)+len(items))
+	for _, b := range m.blocks {
+		byRound[b.Round] = b
+	}
+	for _, b := range items {
+		byRound[b.Round] = b
+	}
+	if len(byRound) == 0 {
+		return
+	}
+
+	merged := make(blocks, 0, len(byRound))
+	for _, b := range byRound {
+		merged = append(merged, b)
+	}
+	sort.Slice(merged, func(i, j int) bool {
+		return merged[i].Round > merged[j].Round
+	})
+
+	// find the selected round, or the closest older round.
+	idx := sort.Search(len(merged), func(i int) bool {
+		return merged[i].Round <= selected
+	})
+	if idx == len(merged) {
+		idx--
+	}
+
+	// keep the contiguous rounds around the selection.
+	start, end := idx, idx+1
+	for start > 0 && merged[start-1].Round == merged[start].Round+1 {
+		start--
+	}
+	for end < len(merged) && merged[end-1].Round == merged[end].Round+1 {
+		end++
+	}
+
+	// cap the cache around the selection.
+	if end-start > maxBlocks {
+		if idx-maxBlocks/2 > start {
+			start = idx - maxBlocks/2
+		}
+		if start+maxBlocks < end {
+			end = start + maxBlocks
+		}
+		start = end - maxBlocks
+	}
+
+	m.blocks = merged[start:end]
+	m.selectedRound = merged[idx].Round
+}
+
+// cursorRound returns the round under the block table cursor.
+func (m Model) cursorRound() uint64 {
+	if len(m.blocks) == 0 || m.table.CursorIsPastBottom() {
+		return 0
+	}
+	if blk, ok := m.table.SelectedRow().(BlockItem); ok {
+		return blk.Round
+	}
+	return 0
+}
+
+// hasRound returns true if the round is in the cache.
+func (m Model) hasRound(round uint64) bool {
+	idx := sort.Search(len(m.blocks), func(i int) bool {
+		return m.blocks[i].Round <= round
+	})
+	return idx < len(m.blocks) && m.blocks[idx].Round == round
+}
+
+// selectRound moves the block table cursor to the round, or the closest older
+// round when it isn't cached.
+func (m *Model) selectRound(round uint64) {
+	if len(m.blocks) == 0 {
+		return
+	}
+	idx := sort.Search(len(m.blocks), func(i int) bool {
+		return m.blocks[i].Round <= round
+	})
+	if idx == len(m.blocks) {
+		idx--
+	}
+
+	// The table only moves relative to the cursor, use pages to get close.
+	if idx == 0 {
+		m.table.GoTop()
+		return
+	}
+	for m.pageHeight > 0 && m.table.Cursor()+m.pageHeight <= idx {
+		m.table.GoPageDown()
+	}
+	for m.pageHeight > 0 && m.table.Cursor()-m.pageHeight >= idx {
+		m.table.GoPageUp()
+	}
+	for m.table.Cursor() < idx && !m.table.CursorIsAtBottom() {
+		m.table.GoDown()
+	}
+	for m.table.Cursor() > idx {
+		m.table.GoUp()
+	}
+}
+
+// gotoRound selects a round, fetching it first if necessary.
+func (m *Model) gotoRound(round uint64) tea.Cmd {
+	if round > m.latestRound {
+		m.pageErr = fmt.Errorf("round %d is not available, the latest round is %d", round, m.latestRound)
+		return nil
+	}
+	if m.hasRound(round) {
+		m.selectRound(round)
+		m.selectedRound = round
+		return nil
+	}
+	m.loadingPage = true
+	return m.jumpToRoundCmd(round)
+}
+
+// nextPageCmd lazily loads more blocks when the cursor reaches the edge of
+// the cache.
+func (m *Model) nextPageCmd() tea.Cmd {
+	if m.loadingPage || len(m.blocks) == 0 {
+		return nil
+	}
+
+	switch {
+	case m.table.CursorIsAtBottom():
+		oldest := m.blocks[len(m.blocks)-1].Round
+		if oldest == 0 {
+			return nil
+		}
+		last := oldest - 1
+		first := uint64(0)
+		if last >= blocksPageSize {
+			first = last - blocksPageSize + 1
+		}
+		m.loadingPage = true
+		return m.getPageCmd(first, last)
+	case m.table.CursorIsAtTop():
+		newest := m.blocks[0].Round
+		if newest >= m.latestRound {
+			return nil
+		}
+		last := newest + blocksPageSize
+		if last > m.latestRound {
+			last = m.latestRound
+		}
+		m.loadingPage = true
+		return m.getPageCmd(newest+1, last)
+	}
+
+	return nil
+}
+
+// openPayset displays the transactions of a block.
+func (m *Model) openPayset(block BlockItem) {
+	m.state = paysetState
+	m.selectedRound = block.Round
+	m.transactions = make([]transactionItem, 0)
+	m.expanded = make(map[string]bool)
+	for i := range block.Block.Block.Payset {
+		t := block.Block.Block.Payset[i]
+		m.transactions = append(m.transactions, makeTransactionItem(block.Block.Block.BlockHeader, i, &t))
+	}
+	m.initTransactions()
+}
+
 func (m *Model) initBlocks() {
 	t := table.New(blockTableHeader, 0, 0)
 	t.KeyMap.Up.SetKeys(append(t.KeyMap.Up.Keys(), "k")...)
@@ -158,15 +329,35 @@ func (m *Model) initBlocks() {
 	m.table = t
 	m.setSize(m.width, m.height)
 	m.updateBlockTable()
+	m.selectRound(m.selectedRound)
 }
 
 // updateBlocks mimics the tea.Model update function.
 func (m Model) updateBlocks(msg tea.Msg) (Model, tea.Cmd) {
-	switch msg.(type) {
-	case BlocksMsg:
-		if m.state == blockState {
-			m.updateBlockTable()
+	switch msg := msg.(type) {
+	case BlocksMsg, pageBlocksMsg:
+		m.updateBlockTable()
+		m.selectRound(m.selectedRound)
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, util.AppKeys.GotoRound):
+			return m, m.openPrompt(gotoPrompt)
+		case key.Matches(msg, util.AppKeys.BlockHeader):
+			if len(m.blocks) == 0 || m.table.CursorIsPastBottom() {
+				return m, nil
+			}
+			if blk, ok := m.table.SelectedRow().(BlockItem); ok {
+				m.selectedRound = blk.Round
+				m.headerBlock = blk
+				m.state = headerState
+				m.initBlockHeader(blk)
+			}
+			return m, nil
+		case key.Matches(msg, util.AppKeys.Search):
+			return m, m.openPrompt(searchPrompt)
 		}
+		m.selectedRound = m.cursorRound()
+		return m, m.nextPageCmd()
 	}
 
 	return m, nil
@@ -9,6 +9,7 @@ import (
 
 	table "github.com/calyptia/go-bubble-table"
 	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -26,9 +27,22 @@ const (
 	blockState = iota
 	paysetState
 	txnState
+	searchState
+	groupState
+	headerState
 )
 
-const initialBlocks = 25
+const (
+	initialBlocks = 25
+
+	// blocksPageSize is the number of rounds fetched when paging backwards or
+	// jumping to a round which is not cached.
+	blocksPageSize = 25
+
+	// maxBlocks caps the number of cached blocks so that a long session
+	// doesn't grow forever.
+	maxBlocks = 1000
+)
 
 type blocks []BlockItem
 type txnItems []transactionItem
@@ -46,17 +60,40 @@ type Model struct {
 	style        *style.Styles
 
 	// for blocks page
-	blocks blocks
+	blocks        blocks
+	latestRound   uint64
+	selectedRound uint64
+	loadingPage   bool
+	pageErr       error
+	headerBlock   BlockItem
+
+	// go to round and search prompt
+	prompt     textinput.Model
+	promptMode promptMode
+
+	// for search page
+	searchQuery   string
+	searchResults []searchItem
+	searching     bool
 
 	// listener for status command, used for recovery
 	statusRound uint64
 
 	// cache for transactions page
-	transactions txnItems
-
-	table     table.Model
-	txnView   viewport.Model
-	requestor *messages.Requestor
+	transactions  txnItems
+	visibleTxns   txnItems
+	paysetOptions paysetOptions
+	expanded      map[string]bool
+
+	// transaction being displayed, and the state to return to
+	txn       transactionItem
+	txnReturn state
+	viewTitle string
+
+	table      table.Model
+	pageHeight int
+	txnView    viewport.Model
+	requestor  *messages.Requestor
 }
 
 // New constructs the explorer Model.
@@ -69,17 +106,29 @@ func New(styles *style.Styles, requestor *messages.Requestor, width, widthMargin
 		height:       height,
 		heightMargin: heightMargin,
 		requestor:    requestor,
+		prompt:       textinput.New(),
 	}
+	m.prompt.CharLimit = 80
 	m.initBlocks()
 	return m
 }
 
+// CapturingInput is part of the util.InputCapturer interface.
+func (m Model) CapturingInput() bool {
+	return m.prompt.Focused()
+}
+
 // BlocksMsg contains new block information.
 type BlocksMsg struct {
 	Blocks []BlockItem
 	Err    error
 }
 
+// GotoRoundMsg selects a round in the block table.
+type GotoRoundMsg struct {
+	Round uint64
+}
+
 // initBlocksCmd is the initializer command.
 func (m Model) initBlocksCmd() tea.Msg {
 	status, err := m.requestor.Client.Status().Do(context.Background())
@@ -88,27 +137,93 @@ func (m Model) initBlocksCmd() tea.Msg {
 			Err: err,
 		}
 	}
-	return m.getBlocks(status.LastRound-initialBlocks, status.LastRound)()
+	first := uint64(0)
+	if status.LastRound > initialBlocks {
+		first = status.LastRound - initialBlocks
+	}
+	return m.getBlocks(first, status.LastRound)()
 }
 
-func (m *Model) getBlocks(first, last uint64) tea.Cmd {
+// GetBlock downloads and decodes a block with its certificate.
+func GetBlock(requestor *messages.Requestor, round uint64) (BlockItem, error) {
+	raw, err := requestor.Client.BlockRaw(round).Do(context.Background())
+	if err != nil {
+		return BlockItem{}, err
+	}
+	return makeBlockItem(round, raw)
+}
+
+// fetchBlocks downloads the blocks from last to first, newest first.
+func (m Model) fetchBlocks(first, last uint64) ([]BlockItem, error) {
+	var result []BlockItem
+	for i := last; i >= first; i-- {
+		item, err := GetBlock(m.requestor, i)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, item)
+
+		// avoid wrapping around when the first round is zero.
+		if i == 0 {
+			break
+		}
+	}
+	return result, nil
+}
+
+func (m Model) getBlocks(first, last uint64) tea.Cmd {
 	return func() tea.Msg {
-		var result BlocksMsg
-		for i := last; i >= first; i-- {
-			block, err := m.requestor.Client.BlockRaw(i).Do(context.Background())
-			if err != nil {
-				result.Err = err
-				return result
-			}
-			item := BlockItem{Round: i}
-			err = lenientDecode(block, &item.Block)
-			if err != nil {
-				result.Err = err
-				return result
-			}
-			result.Blocks = append(result.Blocks, item)
+		blks, err := m.fetchBlocks(first, last)
+		return BlocksMsg{
+			Blocks: blks,
+			Err:    err,
+		}
+	}
+}
+
+// pageBlocksMsg contains blocks requested while paging through history or
+// jumping to a round. It is separate from BlocksMsg so that other bubbles only
+// see the live block stream.
+type pageBlocksMsg struct {
+	Blocks []BlockItem
+	Err    error
+
+	// jump is set when round should be selected once the blocks are loaded.
+	jump  bool
+	round uint64
+
+	// show is opened once the blocks are loaded.
+	show *ShowTxnMsg
+}
+
+func (m Model) getPageCmd(first, last uint64) tea.Cmd {
+	return func() tea.Msg {
+		blks, err := m.fetchBlocks(first, last)
+		return pageBlocksMsg{
+			Blocks: blks,
+			Err:    err,
+		}
+	}
+}
+
+// jumpToRoundCmd fetches a page of blocks around round and selects it.
+func (m Model) jumpToRoundCmd(round uint64) tea.Cmd {
+	last := round + blocksPageSize/2
+	if last > m.latestRound {
+		last = m.latestRound
+	}
+	first := uint64(0)
+	if last >= blocksPageSize {
+		first = last - blocksPageSize + 1
+	}
+	return func() tea.Msg {
+		blks, err := m.fetchBlocks(first, last)
+		return pageBlocksMsg{
+			Blocks: blks,
+			Err:    err,
+			jump:   true,
+			round:  round,
 		}
-		return result
 	}
 }
 
@@ -127,16 +242,7 @@ func (m Model) nextBlockCmd(round uint64) tea.Cmd {
 		if err != nil {
 			return BlocksMsg{Err: err}
 		}
-		blk, err := m.requestor.Client.BlockRaw(round).Do(context.Background())
-		if err != nil {
-			return BlocksMsg{Err: err}
-		}
-		item := BlockItem{Round: round}
-		err = lenientDecode(blk, &item.Block)
-		if err != nil {
-			return err
-		}
-
+		item, err := GetBlock(m.requestor, round)
 		if err != nil {
 			return BlocksMsg{
 				Err: err,
@@ -152,48 +258,89 @@ func (m *Model) setSize(width, height int) {
 	m.width = width
 	m.height = height
 	verticalFrameSize := m.style.Bottom.GetVerticalFrameSize()
-	m.table.SetSize(width-m.widthMargin, height-m.heightMargin-verticalFrameSize)
+	tableHeight := height - m.heightMargin - verticalFrameSize - lipgloss.Height(m.promptView())
+	m.table.SetSize(width-m.widthMargin, tableHeight)
+	// the table header uses one line
+	m.pageHeight = tableHeight - 1
 	m.txnView.Width = width - m.widthMargin
 	m.txnView.Height = height - m.heightMargin - lipgloss.Height(m.headerView()) - lipgloss.Height(m.footerView())
 }
 
 // Update is part of the tea.Model interface.
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	return m.update(msg)
+}
+
+// UpdateKeys is part of the util.KeyUpdater interface, it enables the key
+// bindings which apply to the current state.
+func (m Model) UpdateKeys(active bool) {
+	util.AppKeys.GotoRound.SetEnabled(active && m.state == blockState)
+	util.AppKeys.BlockHeader.SetEnabled(active && m.state == blockState)
+	util.AppKeys.Search.SetEnabled(active && (m.state == blockState || m.state == searchState))
+	paysetKeys := active && m.state == paysetState
+	util.AppKeys.SortColumn.SetEnabled(paysetKeys)
+	util.AppKeys.SortOrder.SetEnabled(paysetKeys)
+	util.AppKeys.TypeFilter.SetEnabled(paysetKeys)
+	util.AppKeys.SenderFilter.SetEnabled(paysetKeys)
+	util.AppKeys.Expand.SetEnabled(paysetKeys)
+	util.AppKeys.GroupSummary.SetEnabled(paysetKeys)
+}
+
+func (m Model) update(msg tea.Msg) (Model, tea.Cmd) {
 	var updateCmd tea.Cmd
 	var cmds []tea.Cmd
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		if m.prompt.Focused() {
+			return m.updatePrompt(msg)
+		}
+
 		// navigate into explorer views
 		switch {
 		case key.Matches(msg, util.AppKeys.Forward):
 			switch m.state {
 			case blockState:
+				if len(m.blocks) == 0 {
+					break
+				}
 				// Select transactions.
-				m.state = paysetState
 				switch block := m.table.SelectedRow().(type) {
 				case BlockItem:
-					m.transactions = make([]transactionItem, 0)
-					for _, txn := range block.Block.Block.Payset {
-						t := txn
-						m.transactions = append(m.transactions, transactionItem{&t})
-					}
+					m.openPayset(block)
 				}
-				m.initTransactions()
+			case headerState:
+				m.openPayset(m.headerBlock)
 			case paysetState:
+				if len(m.visibleTxns) == 0 {
+					break
+				}
 				m.state = txnState
+				m.txnReturn = paysetState
 				switch txn := m.table.SelectedRow().(type) {
 				case transactionItem:
-					m.initTransaction(txn.SignedTxnInBlock)
+					m.initTransaction(txn)
+				}
+			case searchState:
+				if len(m.searchResults) == 0 {
+					break
+				}
+				m.state = txnState
+				m.txnReturn = searchState
+				switch result := m.table.SelectedRow().(type) {
+				case searchItem:
+					m.initTransaction(result.transactionItem)
 				}
 			}
 
 		// navigate out of explorer views
 		case key.Matches(msg, util.AppKeys.Back):
 			switch m.state {
-			case paysetState:
+			case paysetState, searchState, headerState:
 				m.state = blockState
 				m.initBlocks()
 			case txnState:
+				m.state = m.txnReturn
+			case groupState:
 				m.state = paysetState
 			}
 		}
@@ -221,14 +368,66 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		m.err = nil
 
-		// append Blocks
-		backup := m.blocks
-		m.blocks = msg.Blocks
-		m.blocks = append(m.blocks, backup...)
-		if len(m.blocks) > 0 {
-			next = m.blocks[0].Round + 1
+		// follow the newest block unless something older is selected.
+		selected := m.selectedRound
+		following := len(m.blocks) == 0
+		if m.state == blockState && len(m.blocks) > 0 {
+			selected = m.cursorRound()
+			following = m.table.Cursor() == 0 && m.blocks[0].Round == m.latestRound
+		}
+		for _, blk := range msg.Blocks {
+			if blk.Round > m.latestRound {
+				m.latestRound = blk.Round
+			}
 		}
+		if following {
+			selected = m.latestRound
+		}
+		m.addBlocks(msg.Blocks, selected)
+
+		next = m.latestRound + 1
 		cmds = append(cmds, m.nextBlockCmd(next))
+
+	case GotoRoundMsg:
+		m.prompt.Blur()
+		m.state = blockState
+		m.initBlocks()
+		return m, m.gotoRound(msg.Round)
+
+	case ShowTxnMsg:
+		m.prompt.Blur()
+		if m.showTxn(msg) {
+			return m, nil
+		}
+		m.state = blockState
+		m.initBlocks()
+		return m, m.showTxnCmd(msg)
+
+	case searchResultsMsg:
+		m.searching = false
+		m.searchQuery = msg.query
+		m.searchResults = msg.results
+		m.state = searchState
+		m.initSearch()
+		return m, nil
+
+	case pageBlocksMsg:
+		m.loadingPage = false
+		m.pageErr = msg.Err
+		selected := m.selectedRound
+		if m.state == blockState {
+			selected = m.cursorRound()
+		}
+		if msg.jump {
+			selected = msg.round
+		}
+		m.addBlocks(msg.Blocks, selected)
+		if msg.show != nil && msg.Err == nil {
+			if m.showTxn(*msg.show) {
+				return m, nil
+			}
+			m.pageErr = fmt.Errorf("round %d is not available", msg.show.Round)
+		}
 	}
 
 	t, tableCmd := m.table.Update(msg)
@@ -240,8 +439,12 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m, updateCmd = m.updateBlocks(msg)
 		return m, tea.Batch(append(cmds, updateCmd)...)
 	case paysetState:
-		return m, nil
-	case txnState:
+		m, updateCmd = m.updatePayset(msg)
+		return m, tea.Batch(append(cmds, updateCmd)...)
+	case searchState:
+		m, updateCmd = m.updateSearch(msg)
+		return m, tea.Batch(append(cmds, updateCmd)...)
+	case txnState, groupState, headerState:
 		m.txnView, updateCmd = m.txnView.Update(msg)
 		return m, tea.Batch(append(cmds, updateCmd)...)
 	}
@@ -263,9 +466,9 @@ func (m Model) View() string {
 		return bldr.String()
 	}
 	switch m.state {
-	case blockState, paysetState:
-		return prefix + m.style.Bottom.Render(m.table.View())
-	case txnState:
+	case blockState, paysetState, searchState:
+		return prefix + m.style.Bottom.Render(lipgloss.JoinVertical(0, m.promptView(), m.table.View()))
+	case txnState, groupState, headerState:
 		return prefix + m.viewTransaction()
 	}
 	return ""
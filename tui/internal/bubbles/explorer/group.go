@@ -0,0 +1,220 @@
+package explorer
+
+import (
+	"encoding/base64"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/reflow/indent"
+
+	"github.com/algorand/go-algorand-sdk/v2/types"
+)
+
+// groupStyles alternate so that neighbouring groups can be told apart.
+var groupStyles = []lipgloss.Style{
+	lipgloss.NewStyle().Foreground(lipgloss.Color("#0693E3")).Bold(true),
+	lipgloss.NewStyle().Foreground(lipgloss.Color("#6DD588")).Bold(true),
+}
+
+func groupID(group types.Digest) string {
+	return base64.StdEncoding.EncodeToString(group[:])
+}
+
+// groupSizes counts the transactions in each group of the block.
+func groupSizes(txns txnItems) map[types.Digest]int {
+	sizes := make(map[types.Digest]int)
+	for _, t := range txns {
+		if t.Txn.Group != (types.Digest{}) {
+			sizes[t.Txn.Group]++
+		}
+	}
+	return sizes
+}
+
+// markGroups brackets neighbouring top level transactions of the same group.
+func markGroups(tops txnItems, sizes map[types.Digest]int) {
+	band := 0
+	for i := range tops {
+		group := tops[i].Txn.Group
+		if group == (types.Digest{}) {
+			continue
+		}
+		first := i == 0 || tops[i-1].Txn.Group != group
+		last := i == len(tops)-1 || tops[i+1].Txn.Group != group
+		switch {
+		case first && last:
+			tops[i].bracket = "["
+		case first:
+			tops[i].bracket = "┌"
+		case last:
+			tops[i].bracket = "└"
+		default:
+			tops[i].bracket = "│"
+		}
+		if first {
+			band++
+			tops[i].groupLabel = fmt.Sprintf("%s.. (%d)", groupID(group)[:8], sizes[group])
+		}
+		tops[i].band = band % len(groupStyles)
+	}
+}
+
+// renderBracket draws the group marker in front of a row.
+func (t transactionItem) renderBracket() string {
+	if t.bracket == "" {
+		return "  "
+	}
+	return groupStyles[t.band].Render(t.bracket) + " "
+}
+
+// flow tracks the credits and debits of a balance separately to avoid
+// overflowing asset amounts.
+type flow struct {
+	in  uint64
+	out uint64
+}
+
+func (f flow) String() string {
+	if f.in >= f.out {
+		return fmt.Sprintf("+%d", f.in-f.out)
+	}
+	return fmt.Sprintf("-%d", f.out-f.in)
+}
+
+// algos formats a microalgo flow.
+func (f flow) algos() string {
+	if f.in >= f.out {
+		return fmt.Sprintf("+%f", types.MicroAlgos(f.in-f.out).ToAlgos())
+	}
+	return fmt.Sprintf("-%f", types.MicroAlgos(f.out-f.in).ToAlgos())
+}
+
+// balanceKey identifies an asset balance of an account, asset 0 is ALGO.
+type balanceKey struct {
+	addr  types.Address
+	asset uint64
+}
+
+type balanceEffects map[balanceKey]flow
+
+func (e balanceEffects) credit(addr types.Address, asset uint64, amount uint64) {
+	if addr.IsZero() || amount == 0 {
+		return
+	}
+	f := e[balanceKey{addr, asset}]
+	f.in += amount
+	e[balanceKey{addr, asset}] = f
+}
+
+func (e balanceEffects) debit(addr types.Address, asset uint64, amount uint64) {
+	if addr.IsZero() || amount == 0 {
+		return
+	}
+	f := e[balanceKey{addr, asset}]
+	f.out += amount
+	e[balanceKey{addr, asset}] = f
+}
+
+// add records the balance changes of a transaction and its inner
+// transactions. Asset creation is not included.
+func (e balanceEffects) add(stxn *types.SignedTxnWithAD) {
+	txn := &stxn.Txn
+	e.debit(txn.Sender, 0, uint64(txn.Fee))
+	e.credit(txn.Sender, 0, uint64(stxn.SenderRewards))
+
+	switch txn.Type {
+	case types.PaymentTx:
+		e.debit(txn.Sender, 0, uint64(txn.Amount))
+		e.credit(txn.Receiver, 0, uint64(txn.Amount))
+		e.credit(txn.Receiver, 0, uint64(stxn.ReceiverRewards))
+		if !txn.CloseRemainderTo.IsZero() {
+			e.debit(txn.Sender, 0, uint64(stxn.ClosingAmount))
+			e.credit(txn.CloseRemainderTo, 0, uint64(stxn.ClosingAmount))
+			e.credit(txn.CloseRemainderTo, 0, uint64(stxn.CloseRewards))
+		}
+	case types.AssetTransferTx:
+		from := txn.Sender
+		if !txn.AssetSender.IsZero() {
+			from = txn.AssetSender
+		}
+		asset := uint64(txn.XferAsset)
+		e.debit(from, asset, txn.AssetAmount)
+		e.credit(txn.AssetReceiver, asset, txn.AssetAmount)
+		if !txn.AssetCloseTo.IsZero() {
+			e.debit(from, asset, stxn.AssetClosingAmount)
+			e.credit(txn.AssetCloseTo, asset, stxn.AssetClosingAmount)
+		}
+	}
+
+	for i := range stxn.EvalDelta.InnerTxns {
+		e.add(&stxn.EvalDelta.InnerTxns[i])
+	}
+}
+
+// groupDetails renders the group members and the net balance effect.
+func groupDetails(members txnItems, heading lipgloss.Style) string {
+	var b strings.Builder
+	effects := make(balanceEffects)
+
+	b.WriteString(heading.Render("Transactions"))
+	b.WriteString("\n")
+	for _, t := range members {
+		fmt.Fprintf(&b, "  [%d] %-6s %s  %s\n", t.intra, t.Txn.Type, t.Txn.Sender, formatAmount(t.SignedTxnInBlock))
+		effects.add(&t.SignedTxnWithAD)
+	}
+
+	keys := make([]balanceKey, 0, len(effects))
+	for k := range effects {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].addr != keys[j].addr {
+			return keys[i].addr.String() < keys[j].addr.String()
+		}
+		return keys[i].asset < keys[j].asset
+	})
+
+	b.WriteString("\n")
+	b.WriteString(heading.Render("Net balance effect"))
+	b.WriteString("\n")
+	var prev types.Address
+	for i, k := range keys {
+		if i == 0 || k.addr != prev {
+			fmt.Fprintf(&b, "  %s\n", k.addr)
+			prev = k.addr
+		}
+		if k.asset == 0 {
+			fmt.Fprintf(&b, "    %-16s %s\n", "ALGO", effects[k].algos())
+		} else {
+			fmt.Fprintf(&b, "    %-16s %s\n", fmt.Sprintf("asset %d", k.asset), effects[k])
+		}
+	}
+
+	return b.String()
+}
+
+// initGroup displays the summary of the selected transaction's group.
+func (m *Model) initGroup(txn transactionItem) error {
+	// inner transactions belong to the group of their top level transaction.
+	if txn.intra < len(m.transactions) {
+		txn = m.transactions[txn.intra]
+	}
+	group := txn.Txn.Group
+	if group == (types.Digest{}) {
+		return fmt.Errorf("transaction %d is not part of a group", txn.intra)
+	}
+
+	var members txnItems
+	for _, t := range m.transactions {
+		if t.Txn.Group == group {
+			members = append(members, t)
+		}
+	}
+
+	m.viewTitle = fmt.Sprintf("Group: %s (%d txns)", groupID(group), len(members))
+	m.txnView.YOffset = 0
+	m.txnView.SetContent(indent.String(groupDetails(members, m.style.StatusBoldText), 6))
+	return nil
+}
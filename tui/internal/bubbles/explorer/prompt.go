@@ -0,0 +1,132 @@
+package explorer
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/algorand/go-algorand-sdk/v2/types"
+
+	"github.com/winder/algorand-navigator/tui/internal/util"
+)
+
+type promptMode int
+
+const (
+	gotoPrompt promptMode = iota
+	searchPrompt
+	senderPrompt
+)
+
+// openPrompt focuses the prompt line for the given mode.
+func (m *Model) openPrompt(mode promptMode) tea.Cmd {
+	m.promptMode = mode
+	m.pageErr = nil
+	m.prompt.Reset()
+	switch mode {
+	case gotoPrompt:
+		m.prompt.Prompt = "Go to round: "
+		m.prompt.Placeholder = "round number"
+	case searchPrompt:
+		m.prompt.Prompt = "Search: "
+		m.prompt.Placeholder = "txid, address, asset ID or app ID"
+	case senderPrompt:
+		m.prompt.Prompt = "Filter sender: "
+		m.prompt.Placeholder = "address, leave empty to clear"
+	}
+	return m.prompt.Focus()
+}
+
+// submitPrompt handles the prompt value, an error keeps the prompt open.
+func (m *Model) submitPrompt(value string) (tea.Cmd, error) {
+	switch m.promptMode {
+	case gotoPrompt:
+		round, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("'%s' is not a valid round", value)
+		}
+		return m.gotoRound(round), nil
+	case searchPrompt:
+		q, err := parseQuery(value)
+		if err != nil {
+			return nil, err
+		}
+		m.searching = true
+		return m.searchCmd(value, q), nil
+	case senderPrompt:
+		var sender types.Address
+		if value != "" {
+			var err error
+			sender, err = types.DecodeAddress(value)
+			if err != nil {
+				return nil, fmt.Errorf("'%s' is not a valid address", value)
+			}
+		}
+		m.paysetOptions.sender = sender
+		m.initTransactions()
+		return nil, nil
+	}
+	return nil, nil
+}
+
+func (m Model) updatePrompt(msg tea.KeyMsg) (Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, util.AppKeys.Back):
+		m.prompt.Blur()
+		m.prompt.Reset()
+		m.pageErr = nil
+		return m, nil
+	case key.Matches(msg, util.AppKeys.Forward):
+		m.pageErr = nil
+		cmd, err := m.submitPrompt(strings.TrimSpace(m.prompt.Value()))
+		if err != nil {
+			m.pageErr = err
+			return m, nil
+		}
+		m.prompt.Blur()
+		m.prompt.Reset()
+		return m, cmd
+	}
+
+	var cmd tea.Cmd
+	m.prompt, cmd = m.prompt.Update(msg)
+	return m, cmd
+}
+
+// promptView is a single line above the table used for prompts and paging
+// information.
+func (m Model) promptView() string {
+	var line string
+	switch {
+	case m.prompt.Focused() && m.pageErr != nil:
+		line = fmt.Sprintf("%s  %s", m.prompt.View(), m.pageErr)
+	case m.prompt.Focused():
+		line = m.prompt.View()
+	case m.pageErr != nil:
+		line = fmt.Sprintf("Error: %s", m.pageErr)
+	case m.loadingPage:
+		line = "Loading blocks..."
+	case m.searching:
+		line = "Searching..."
+	case m.state == paysetState:
+		top, inner := m.shownTxns()
+		line = fmt.Sprintf("Round %d, showing %d of %d transactions", m.selectedRound, top, len(m.transactions))
+		if inner > 0 {
+			line += fmt.Sprintf(" and %d inner transactions", inner)
+		}
+		if filters := m.paysetOptions.describe(); filters != "" {
+			line += fmt.Sprintf(" (%s)", filters)
+		}
+	case m.state == searchState:
+		line = fmt.Sprintf("%d results for '%s'", len(m.searchResults), m.searchQuery)
+		if len(m.searchResults) >= maxSearchResults {
+			line += fmt.Sprintf(", only the first %d are shown", maxSearchResults)
+		}
+	case len(m.blocks) > 0:
+		line = fmt.Sprintf("Rounds %d - %d (latest %d)", m.blocks[len(m.blocks)-1].Round, m.blocks[0].Round, m.latestRound)
+	}
+	return strings.ReplaceAll(line, "\n", " ")
+}
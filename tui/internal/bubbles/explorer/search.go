@@ -0,0 +1,270 @@
+package explorer
+
+import (
+	"encoding/base32"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	table "github.com/calyptia/go-bubble-table"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/algorand/go-algorand-sdk/v2/types"
+
+	"github.com/winder/algorand-navigator/tui/internal/util"
+)
+
+// maxSearchResults limits the size of the results table.
+const maxSearchResults = 1000
+
+// searchItem is a transaction found by a search.
+type searchItem struct {
+	transactionItem
+	round uint64
+}
+
+var searchTableHeader = []string{"  ROUND", "intra", "type", "amount", "sender", "id"}
+
+// Render implements the Row interface to display a row of data.
+func (i searchItem) Render(w io.Writer, model table.Model, index int) {
+	var cursor string
+	if index == model.Cursor() {
+		cursor = "> "
+	} else {
+		cursor = "  "
+	}
+
+	cursor = activeStyle.Render(cursor)
+	round := keyStyle.Render(strconv.FormatUint(i.round, 10))
+	id := i.id
+	if label := i.innerLabel(); label != "" {
+		id = fmt.Sprintf("%s (%s)", id, label)
+	}
+	rest := fmt.Sprintf("\t%d\t%s\t%s\t%s\t%s",
+		i.intra,
+		i.Txn.Type,
+		formatAmount(i.SignedTxnInBlock),
+		i.Txn.Sender.String(),
+		id)
+	if index == model.Cursor() {
+		rest = activeStyle.Render(rest)
+	} else {
+		rest = inactiveStyle.Render(rest)
+	}
+	fmt.Fprintf(w, "%s%s%s\n", cursor, round, rest)
+}
+
+// query is a parsed search term. Only one of the fields is used, except for
+// bare numbers which may be an asset or an application.
+type query struct {
+	txid    string
+	address types.Address
+	asset   uint64
+	app     uint64
+}
+
+func parseQuery(input string) (query, error) {
+	lower := strings.ToLower(input)
+	switch {
+	case strings.HasPrefix(lower, "asset:"):
+		id, err := strconv.ParseUint(strings.TrimSpace(input[len("asset:"):]), 10, 64)
+		if err != nil || id == 0 {
+			return query{}, fmt.Errorf("'%s' is not a valid asset ID", input)
+		}
+		return query{asset: id}, nil
+	case strings.HasPrefix(lower, "app:"):
+		id, err := strconv.ParseUint(strings.TrimSpace(input[len("app:"):]), 10, 64)
+		if err != nil || id == 0 {
+			return query{}, fmt.Errorf("'%s' is not a valid application ID", input)
+		}
+		return query{app: id}, nil
+	}
+
+	if id, err := strconv.ParseUint(input, 10, 64); err == nil && id != 0 {
+		return query{asset: id, app: id}, nil
+	}
+	if addr, err := types.DecodeAddress(input); err == nil {
+		return query{address: addr}, nil
+	}
+	txid := strings.ToUpper(input)
+	if decoded, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(txid); err == nil && len(decoded) == len(types.Digest{}) {
+		return query{txid: txid}, nil
+	}
+
+	return query{}, fmt.Errorf("expected a transaction ID, address, asset ID or application ID")
+}
+
+// addresses returns all accounts referenced by a transaction.
+func addresses(stxn *types.SignedTxnWithAD) []types.Address {
+	txn := &stxn.Txn
+	all := []types.Address{
+		txn.Sender,
+		txn.RekeyTo,
+		txn.Receiver,
+		txn.CloseRemainderTo,
+		txn.AssetSender,
+		txn.AssetReceiver,
+		txn.AssetCloseTo,
+		txn.FreezeAccount,
+		stxn.AuthAddr,
+	}
+	all = append(all, txn.Accounts...)
+
+	result := make([]types.Address, 0, len(all))
+	for _, addr := range all {
+		if !addr.IsZero() {
+			result = append(result, addr)
+		}
+	}
+	return result
+}
+
+// assetIDs returns all assets referenced by a transaction.
+func assetIDs(stxn *types.SignedTxnWithAD) []uint64 {
+	txn := &stxn.Txn
+	result := []uint64{
+		uint64(txn.XferAsset),
+		uint64(txn.ConfigAsset),
+		uint64(txn.FreezeAsset),
+		stxn.ApplyData.ConfigAsset,
+	}
+	for _, id := range txn.ForeignAssets {
+		result = append(result, uint64(id))
+	}
+	return result
+}
+
+// appIDs returns all applications referenced by a transaction.
+func appIDs(stxn *types.SignedTxnWithAD) []uint64 {
+	txn := &stxn.Txn
+	result := []uint64{
+		uint64(txn.ApplicationID),
+		stxn.ApplyData.ApplicationID,
+	}
+	for _, id := range txn.ForeignApps {
+		result = append(result, uint64(id))
+	}
+	return result
+}
+
+func containsID(ids []uint64, id uint64) bool {
+	for _, i := range ids {
+		if i == id {
+			return true
+		}
+	}
+	return false
+}
+
+// matches checks the query against everything except the transaction ID.
+func (q query) matches(stxn *types.SignedTxnWithAD) bool {
+	switch {
+	case !q.address.IsZero():
+		for _, addr := range addresses(stxn) {
+			if addr == q.address {
+				return true
+			}
+		}
+	case q.asset != 0 || q.app != 0:
+		return (q.asset != 0 && containsID(assetIDs(stxn), q.asset)) ||
+			(q.app != 0 && containsID(appIDs(stxn), q.app))
+	}
+	return false
+}
+
+// searchResultsMsg contains the transactions found by a search.
+type searchResultsMsg struct {
+	query   string
+	results []searchItem
+}
+
+func searchInner(parent transactionItem, round uint64, q query, results *[]searchItem) {
+	for i, inner := range parent.EvalDelta.InnerTxns {
+		if len(*results) >= maxSearchResults {
+			return
+		}
+		item := makeInnerItem(parent, inner, i)
+		if q.matches(&item.SignedTxnWithAD) {
+			*results = append(*results, searchItem{transactionItem: item, round: round})
+		}
+		searchInner(item, round, q, results)
+	}
+}
+
+// searchBlocks finds all transactions matching the query, newest first.
+func searchBlocks(blks blocks, q query) []searchItem {
+	var results []searchItem
+	for _, blk := range blks {
+		header := blk.Block.Block.BlockHeader
+		for intra := range blk.Block.Block.Payset {
+			if len(results) >= maxSearchResults {
+				return results
+			}
+			stib := &blk.Block.Block.Payset[intra]
+
+			// computing the ID is expensive, only do it when needed.
+			if q.txid != "" {
+				item := makeTransactionItem(header, intra, stib)
+				if item.id == q.txid {
+					results = append(results, searchItem{transactionItem: item, round: blk.Round})
+				}
+				continue
+			}
+
+			matched := q.matches(&stib.SignedTxnWithAD)
+			if !matched && len(stib.EvalDelta.InnerTxns) == 0 {
+				continue
+			}
+			item := makeTransactionItem(header, intra, stib)
+			if matched {
+				results = append(results, searchItem{transactionItem: item, round: blk.Round})
+			}
+			searchInner(item, blk.Round, q, &results)
+		}
+	}
+	return results
+}
+
+// searchCmd searches the cached blocks in the background.
+func (m Model) searchCmd(input string, q query) tea.Cmd {
+	blks := m.blocks
+	return func() tea.Msg {
+		return searchResultsMsg{
+			query:   input,
+			results: searchBlocks(blks, q),
+		}
+	}
+}
+
+func (m *Model) updateSearchTable() {
+	var rows []table.Row
+	for _, r := range m.searchResults {
+		rows = append(rows, r)
+	}
+
+	m.table.SetRows(rows)
+}
+
+func (m *Model) initSearch() {
+	t := table.New(searchTableHeader, 0, 0)
+	t.KeyMap.Up.SetKeys(append(t.KeyMap.Up.Keys(), "k")...)
+	t.KeyMap.Down.SetKeys(append(t.KeyMap.Down.Keys(), "j")...)
+	t.Styles.Title = m.style.StatusBoldText
+	m.table = t
+	m.setSize(m.width, m.height)
+	m.updateSearchTable()
+}
+
+// updateSearch mimics the tea.Model update function.
+func (m Model) updateSearch(msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if key.Matches(msg, util.AppKeys.Search) {
+			return m, m.openPrompt(searchPrompt)
+		}
+	}
+
+	return m, nil
+}
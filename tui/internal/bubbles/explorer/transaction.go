@@ -26,9 +26,18 @@ var (
 	}()
 )
 
-func (m *Model) initTransaction(txn *types.SignedTxnInBlock) {
+func (m *Model) initTransaction(txn transactionItem) {
+	m.txn = txn
+	m.viewTitle = fmt.Sprintf("Txn: %s", txn.id)
+	if label := txn.innerLabel(); label != "" {
+		m.viewTitle = fmt.Sprintf("%s (%s)", m.viewTitle, label)
+	}
 	m.txnView.YOffset = 0
-	m.txnView.SetContent(indent.String(string(json.Encode(txn)), 6))
+	content := string(json.Encode(txn.SignedTxnInBlock))
+	if txn.Txn.Type == types.ApplicationCallTx {
+		content = appCallDetails(&txn.SignedTxnWithAD, m.style.StatusBoldText) + "\n" + content
+	}
+	m.txnView.SetContent(indent.String(content, 6))
 }
 
 func max(a, b int) int {
@@ -40,8 +49,7 @@ func max(a, b int) int {
 
 func (m Model) headerView() string {
 	info := middleStyle.Render(fmt.Sprintf("%3.f%%", m.txnView.ScrollPercent()*100))
-	//title := titleStyle.Render(fmt.Sprintf("Txn: %s", m.txn.Txn.ID()))
-	title := titleStyle.Render(fmt.Sprintf("Txn: %s", "TODO: Compute ID"))
+	title := titleStyle.Render(m.viewTitle)
 	line := strings.Repeat("─", max(0, m.txnView.Width-lipgloss.Width(title)-lipgloss.Width(info)-1))
 	return lipgloss.JoinHorizontal(lipgloss.Center, title, "─", info, line)
 }
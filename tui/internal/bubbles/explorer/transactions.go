@@ -3,16 +3,119 @@ package explorer
 import (
 	"fmt"
 	"io"
+	"sort"
 	"strconv"
+	"strings"
 
 	table "github.com/calyptia/go-bubble-table"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
 
+	"github.com/algorand/go-algorand-sdk/v2/crypto"
+	"github.com/algorand/go-algorand-sdk/v2/protocol"
+	"github.com/algorand/go-algorand-sdk/v2/protocol/config"
 	"github.com/algorand/go-algorand-sdk/v2/types"
+
+	"github.com/winder/algorand-navigator/tui/internal/util"
 )
 
 // transactionItem is used by the list bubble.
 type transactionItem struct {
 	*types.SignedTxnInBlock
+
+	// intra is the position of the transaction in the block.
+	intra int
+
+	// id is the transaction ID, computed from the full transaction. Inner
+	// transactions use the ID of the top level transaction.
+	id string
+
+	// path is the position of an inner transaction below the top level
+	// transaction, it is empty for top level transactions.
+	path []int
+
+	// expanded is set when the inner transactions are displayed.
+	expanded bool
+
+	// bracket, band and groupLabel mark the rows of a transaction group.
+	bracket    string
+	band       int
+	groupLabel string
+}
+
+// treeKeyStyle is used for inner transaction rows which may be wider than the
+// intra column.
+var treeKeyStyle = keyStyle.Copy().UnsetWidth()
+
+func makeTransactionItem(header types.BlockHeader, intra int, txn *types.SignedTxnInBlock) transactionItem {
+	return transactionItem{
+		SignedTxnInBlock: txn,
+		intra:            intra,
+		id:               crypto.GetTxID(fullTransaction(header, txn)),
+	}
+}
+
+func makeInnerItem(parent transactionItem, txn types.SignedTxnWithAD, index int) transactionItem {
+	path := make([]int, len(parent.path), len(parent.path)+1)
+	copy(path, parent.path)
+	return transactionItem{
+		SignedTxnInBlock: &types.SignedTxnInBlock{SignedTxnWithAD: txn},
+		intra:            parent.intra,
+		id:               parent.id,
+		path:             append(path, index),
+	}
+}
+
+// innerLabel describes the position of an inner transaction, like "inner 0.2".
+func (t transactionItem) innerLabel() string {
+	if len(t.path) == 0 {
+		return ""
+	}
+	parts := make([]string, 0, len(t.path))
+	for _, i := range t.path {
+		parts = append(parts, strconv.Itoa(i))
+	}
+	return "inner " + strings.Join(parts, ".")
+}
+
+// key uniquely identifies a transaction in the block, including inner
+// transactions.
+func (t transactionItem) key() string {
+	parts := []string{strconv.Itoa(t.intra)}
+	for _, i := range t.path {
+		parts = append(parts, strconv.Itoa(i))
+	}
+	return strings.Join(parts, ".")
+}
+
+// children returns the inner transactions.
+func (t transactionItem) children() []transactionItem {
+	result := make([]transactionItem, 0, len(t.EvalDelta.InnerTxns))
+	for i, inner := range t.EvalDelta.InnerTxns {
+		result = append(result, makeInnerItem(t, inner, i))
+	}
+	return result
+}
+
+// fullTransaction restores the fields which are removed from a transaction
+// when it is stored in a block.
+func fullTransaction(header types.BlockHeader, txn *types.SignedTxnInBlock) types.Transaction {
+	result := txn.Txn
+	if txn.HasGenesisID {
+		result.GenesisID = header.GenesisID
+	}
+	if txn.HasGenesisHash || requireGenesisHash(header.CurrentProtocol) {
+		result.GenesisHash = header.GenesisHash
+	}
+	return result
+}
+
+// requireGenesisHash is true when the protocol removes the mandatory genesis
+// hash from the transactions of a block. Protocols newer than the consensus
+// table of the SDK all require it.
+func requireGenesisHash(proto string) bool {
+	params, ok := config.Consensus[protocol.ConsensusVersion(proto)]
+	return !ok || params.RequireGenesisHash
 }
 
 func formatAmount(txn *types.SignedTxnInBlock) string {
@@ -25,30 +128,174 @@ func formatAmount(txn *types.SignedTxnInBlock) string {
 	return "-"
 }
 
-var transactionTableHeader = []string{"  INTRA", "type", "amount", "sigtype", "fee", "has-note", "sender"}
+var transactionTableHeader = []string{"  INTRA", "group", "type", "amount", "sigtype", "fee", "has-note", "sender", "id"}
 
-func computeTxnRow(b transactionItem) string {
-	var sigtype string
-	if !(b.Sig == types.Signature{}) {
-		sigtype = "ed25519"
-	} else if !b.Msig.Blank() {
-		sigtype = "msig"
-	} else if !b.Lsig.Blank() {
-		sigtype = "lsig"
-	} else {
-		sigtype = "inner-txn"
+func sigType(b transactionItem) string {
+	switch {
+	case !(b.Sig == types.Signature{}):
+		return "ed25519"
+	case !b.Msig.Blank():
+		return "msig"
+	case !b.Lsig.Blank():
+		return "lsig"
+	default:
+		return "inner-txn"
 	}
+}
 
-	return fmt.Sprintf("\t%s\t%s\t%s\t%f\t%t\t%s",
+func computeTxnRow(b transactionItem) string {
+	return fmt.Sprintf("\t%s\t%s\t%s\t%s\t%f\t%t\t%s\t%s",
+		b.groupLabel,
 		b.Txn.Type,
 		formatAmount(b.SignedTxnInBlock),
-		sigtype,
+		sigType(b),
 		b.Txn.Fee.ToAlgos(),
 		len(b.Txn.Note) > 0,
 		b.Txn.Sender.String(),
+		b.id,
 	)
 }
 
+// txnSortKey is a payset table column which can be sorted.
+type txnSortKey int
+
+const (
+	sortIntra txnSortKey = iota
+	sortType
+	sortAmount
+	sortSigType
+	sortFee
+	sortNote
+	sortSender
+	numSortKeys
+)
+
+// sortColumns maps the sort keys to their column in the payset table.
+var sortColumns = [numSortKeys]int{
+	sortIntra:   0,
+	sortType:    2,
+	sortAmount:  3,
+	sortSigType: 4,
+	sortFee:     5,
+	sortNote:    6,
+	sortSender:  7,
+}
+
+// txnTypes are the transaction type filter values, the empty type shows all
+// transactions.
+var txnTypes = []types.TxType{
+	"",
+	types.PaymentTx,
+	types.KeyRegistrationTx,
+	types.AssetConfigTx,
+	types.AssetTransferTx,
+	types.AssetFreezeTx,
+	types.ApplicationCallTx,
+	types.StateProofTx,
+}
+
+// paysetOptions are the sort and filter settings of the payset table. They
+// are kept when moving between blocks.
+type paysetOptions struct {
+	sortKey    txnSortKey
+	descending bool
+	txnType    types.TxType
+	sender     types.Address
+}
+
+// nextTxnType cycles through the type filters.
+func (o *paysetOptions) nextTxnType() {
+	for i, t := range txnTypes {
+		if t == o.txnType {
+			o.txnType = txnTypes[(i+1)%len(txnTypes)]
+			return
+		}
+	}
+	o.txnType = ""
+}
+
+func (o paysetOptions) include(t transactionItem) bool {
+	if o.txnType != "" && t.Txn.Type != o.txnType {
+		return false
+	}
+	if !o.sender.IsZero() && t.Txn.Sender != o.sender {
+		return false
+	}
+	return true
+}
+
+// amount returns the raw transfer amount, zero for other transaction types.
+func amount(t transactionItem) uint64 {
+	switch t.Txn.Type {
+	case types.PaymentTx:
+		return uint64(t.Txn.Amount)
+	case types.AssetTransferTx:
+		return t.Txn.AssetAmount
+	}
+	return 0
+}
+
+func (o paysetOptions) less(a, b transactionItem) bool {
+	switch o.sortKey {
+	case sortIntra:
+		return a.intra < b.intra
+	case sortType:
+		return a.Txn.Type < b.Txn.Type
+	case sortAmount:
+		return amount(a) < amount(b)
+	case sortSigType:
+		return sigType(a) < sigType(b)
+	case sortFee:
+		return a.Txn.Fee < b.Txn.Fee
+	case sortNote:
+		return len(a.Txn.Note) == 0 && len(b.Txn.Note) > 0
+	case sortSender:
+		return a.Txn.Sender.String() < b.Txn.Sender.String()
+	}
+	return false
+}
+
+// apply filters and sorts the transactions, ties keep the block order.
+func (o paysetOptions) apply(txns txnItems) txnItems {
+	result := make(txnItems, 0, len(txns))
+	for _, t := range txns {
+		if o.include(t) {
+			result = append(result, t)
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		if o.descending {
+			return o.less(result[j], result[i])
+		}
+		return o.less(result[i], result[j])
+	})
+	return result
+}
+
+// header marks the sorted column.
+func (o paysetOptions) header() []string {
+	header := make([]string, len(transactionTableHeader))
+	copy(header, transactionTableHeader)
+	arrow := " ▲"
+	if o.descending {
+		arrow = " ▼"
+	}
+	header[sortColumns[o.sortKey]] += arrow
+	return header
+}
+
+// describe summarizes the active filters.
+func (o paysetOptions) describe() string {
+	var parts []string
+	if o.txnType != "" {
+		parts = append(parts, fmt.Sprintf("type: %s", o.txnType))
+	}
+	if !o.sender.IsZero() {
+		parts = append(parts, fmt.Sprintf("sender: %s", o.sender))
+	}
+	return strings.Join(parts, ", ")
+}
+
 func (i transactionItem) Render(w io.Writer, model table.Model, index int) {
 	var cursor string
 	if index == model.Cursor() {
@@ -58,27 +305,94 @@ func (i transactionItem) Render(w io.Writer, model table.Model, index int) {
 	}
 
 	cursor = activeStyle.Render(cursor)
-	intra := keyStyle.Render(strconv.FormatUint(uint64(index), 10))
+	label := strconv.Itoa(i.intra)
+	if len(i.path) > 0 {
+		label = strings.Repeat("  ", len(i.path)-1) + "└ " + i.key()
+	}
+	if len(i.EvalDelta.InnerTxns) > 0 {
+		if i.expanded {
+			label += " ▾"
+		} else {
+			label += " ▸"
+		}
+	}
+	var intra string
+	if len(i.path) > 0 {
+		intra = treeKeyStyle.Render(label)
+	} else {
+		intra = keyStyle.Render(label)
+	}
 	rest := computeTxnRow(i)
 	if index == model.Cursor() {
 		rest = activeStyle.Render(rest)
 	} else {
 		rest = inactiveStyle.Render(rest)
 	}
-	fmt.Fprintf(w, "%s%s%s\n", cursor, intra, rest)
+	fmt.Fprintf(w, "%s%s%s%s\n", cursor, i.renderBracket(), intra, rest)
+}
+
+// appendTree adds the transaction and any expanded inner transactions.
+func (m *Model) appendTree(result txnItems, t transactionItem) txnItems {
+	t.expanded = m.expanded[t.key()]
+	result = append(result, t)
+	if !t.expanded {
+		return result
+	}
+	for _, child := range t.children() {
+		// continue the group bracket past the inner transactions.
+		if t.bracket == "┌" || t.bracket == "│" {
+			child.bracket = "│"
+			child.band = t.band
+		}
+		result = m.appendTree(result, child)
+	}
+	return result
 }
 
 func (m *Model) updateTxnTable() {
+	m.visibleTxns = nil
+	tops := m.paysetOptions.apply(m.transactions)
+	markGroups(tops, groupSizes(m.transactions))
+	for _, t := range tops {
+		m.visibleTxns = m.appendTree(m.visibleTxns, t)
+	}
+
 	var rows []table.Row
-	for _, t := range m.transactions {
+	for _, t := range m.visibleTxns {
 		rows = append(rows, t)
 	}
 
 	m.table.SetRows(rows)
 }
 
+// shownTxns counts the rows of the table, inner transactions are only
+// counted while expanded.
+func (m Model) shownTxns() (top, inner int) {
+	for _, t := range m.visibleTxns {
+		if len(t.path) > 0 {
+			inner++
+		} else {
+			top++
+		}
+	}
+	return top, inner
+}
+
+// toggleExpanded shows or hides the inner transactions of the selected row.
+func (m *Model) toggleExpanded() {
+	if len(m.visibleTxns) == 0 {
+		return
+	}
+	txn, ok := m.table.SelectedRow().(transactionItem)
+	if !ok || len(txn.EvalDelta.InnerTxns) == 0 {
+		return
+	}
+	m.expanded[txn.key()] = !txn.expanded
+	m.updateTxnTable()
+}
+
 func (m *Model) initTransactions() {
-	t := table.New(transactionTableHeader, 0, 0)
+	t := table.New(m.paysetOptions.header(), 0, 0)
 	t.KeyMap.Up.SetKeys(append(t.KeyMap.Up.Keys(), "k")...)
 	t.KeyMap.Down.SetKeys(append(t.KeyMap.Down.Keys(), "j")...)
 	t.Styles.Title = m.style.StatusBoldText
@@ -86,3 +400,41 @@ func (m *Model) initTransactions() {
 	m.setSize(m.width, m.height)
 	m.updateTxnTable()
 }
+
+// updatePayset mimics the tea.Model update function.
+func (m Model) updatePayset(msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, util.AppKeys.SortColumn):
+			m.paysetOptions.sortKey = (m.paysetOptions.sortKey + 1) % numSortKeys
+			m.initTransactions()
+		case key.Matches(msg, util.AppKeys.SortOrder):
+			m.paysetOptions.descending = !m.paysetOptions.descending
+			m.initTransactions()
+		case key.Matches(msg, util.AppKeys.TypeFilter):
+			m.paysetOptions.nextTxnType()
+			m.initTransactions()
+		case key.Matches(msg, util.AppKeys.SenderFilter):
+			return m, m.openPrompt(senderPrompt)
+		case key.Matches(msg, util.AppKeys.Expand):
+			m.toggleExpanded()
+		case key.Matches(msg, util.AppKeys.GroupSummary):
+			if len(m.visibleTxns) == 0 {
+				break
+			}
+			txn, ok := m.table.SelectedRow().(transactionItem)
+			if !ok {
+				break
+			}
+			if err := m.initGroup(txn); err != nil {
+				m.pageErr = err
+				break
+			}
+			m.pageErr = nil
+			m.state = groupState
+		}
+	}
+
+	return m, nil
+}
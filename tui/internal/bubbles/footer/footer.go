@@ -1,10 +1,13 @@
 package footer
 
 import (
+	"time"
+
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 
 	"github.com/winder/algorand-navigator/messages"
+	"github.com/winder/algorand-navigator/tui/internal/bubbles/health"
 	"github.com/winder/algorand-navigator/tui/internal/style"
 )
 
@@ -15,11 +18,15 @@ type Model struct {
 	style  *style.Styles
 
 	network messages.NetworkMsg
+	health  health.Monitor
 }
 
 // New creates the footer Model.
 func New(s *style.Styles) Model {
-	return Model{style: s}
+	return Model{
+		style:  s,
+		health: health.NewMonitor(),
+	}
 }
 
 // Init is part of the tea.Model interface.
@@ -36,6 +43,14 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case messages.NetworkMsg:
 		m.network = msg
+
+	case messages.StatusMsg:
+		// transitions are sent to the app for the event log.
+		if event := m.health.Update(msg, time.Now()); event != nil {
+			return m, func() tea.Msg {
+				return *event
+			}
+		}
 	}
 
 	return m, nil
@@ -49,13 +64,15 @@ func (m Model) View() string {
 	right := m.style.FooterRight.Render(m.network.NodeVersion)
 	//middleText := fmt.Sprintf("%s (Gensis Hash %s)", m.network.GenesisID, m.network.GenesisHash)
 	middleText := m.network.GenesisID
+	indicator := m.health.State.Style().Render("● " + string(m.health.State))
 
 	middle := m.style.FooterMiddle.Copy().
-		Width(m.width - lipgloss.Width(left) - lipgloss.Width(right)).
+		Width(m.width - lipgloss.Width(left) - lipgloss.Width(indicator) - lipgloss.Width(right)).
 		Render(middleText)
 
 	return lipgloss.JoinHorizontal(lipgloss.Top,
 		left,
+		indicator,
 		middle,
 		right,
 	)
@@ -0,0 +1,119 @@
+// Package health evaluates the sync health of the node from successive status
+// updates.
+package health
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/winder/algorand-navigator/messages"
+)
+
+const (
+	// slowThreshold is how long the node may wait for a round before it is
+	// considered slow.
+	slowThreshold = 15 * time.Second
+
+	// stallThreshold is how long the round may stay the same before the node
+	// is considered stalled.
+	stallThreshold = 60 * time.Second
+)
+
+// State is the sync health of the node.
+type State string
+
+// Health states, from healthy to broken.
+const (
+	Unknown     State = "unknown"
+	Synced      State = "synced"
+	Syncing     State = "syncing"
+	FastCatchup State = "fast catchup"
+	Slow        State = "slow"
+	Stalled     State = "stalled"
+	Unsupported State = "unsupported"
+)
+
+// Style returns the colour of a state.
+func (s State) Style() lipgloss.Style {
+	style := lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFDF5")).Padding(0, 1)
+	switch s {
+	case Synced:
+		return style.Background(lipgloss.Color("#2AB56F"))
+	case Syncing, FastCatchup:
+		return style.Background(lipgloss.Color("#0693E3"))
+	case Slow:
+		return style.Background(lipgloss.Color("#E3A322"))
+	case Stalled, Unsupported:
+		return style.Background(lipgloss.Color("#E35D5D"))
+	}
+	return style.Background(lipgloss.Color("#6C6C6C"))
+}
+
+// EventMsg is sent when the health of the node changes.
+type EventMsg struct {
+	Time   time.Time
+	From   State
+	To     State
+	Reason string
+}
+
+// Monitor evaluates successive status updates.
+type Monitor struct {
+	State  State
+	Reason string
+
+	// round is the last round seen and when it was first seen.
+	round     uint64
+	roundTime time.Time
+}
+
+// NewMonitor creates a Monitor, the state is unknown until the first status.
+func NewMonitor() Monitor {
+	return Monitor{State: Unknown, Reason: "waiting for the node status"}
+}
+
+// evaluate returns the health for a status.
+func (m Monitor) evaluate(msg messages.StatusMsg, now time.Time) (State, string) {
+	status := msg.Status
+	waiting := time.Duration(status.TimeSinceLastRound)
+	stuck := now.Sub(m.roundTime)
+	switch {
+	case msg.Error != nil:
+		return Unknown, fmt.Sprintf("unable to fetch the status: %s", msg.Error)
+	case status.StoppedAtUnsupportedRound:
+		return Unsupported, fmt.Sprintf("stopped at round %d, the next protocol is not supported, upgrade the node", status.LastRound)
+	case status.Catchpoint != "":
+		return FastCatchup, fmt.Sprintf("catching up to %s", status.Catchpoint)
+	case m.round == status.LastRound && !m.roundTime.IsZero() && stuck > stallThreshold:
+		return Stalled, fmt.Sprintf("round %d for %s", status.LastRound, stuck.Round(time.Second))
+	case waiting > slowThreshold:
+		return Slow, fmt.Sprintf("waiting %s for round %d", waiting.Round(time.Second), status.LastRound+1)
+	case status.CatchupTime > 0:
+		return Syncing, fmt.Sprintf("syncing for %s, at round %d", time.Duration(status.CatchupTime).Round(time.Second), status.LastRound)
+	}
+	return Synced, fmt.Sprintf("round %d", status.LastRound)
+}
+
+// Update evaluates a status, an event is returned when the state changes.
+func (m *Monitor) Update(msg messages.StatusMsg, now time.Time) *EventMsg {
+	if msg.Error == nil && (msg.Status.LastRound != m.round || m.roundTime.IsZero()) {
+		m.round = msg.Status.LastRound
+		m.roundTime = now
+	}
+
+	state, reason := m.evaluate(msg, now)
+	m.Reason = reason
+	if state == m.State {
+		return nil
+	}
+	event := &EventMsg{
+		Time:   now,
+		From:   m.State,
+		To:     state,
+		Reason: reason,
+	}
+	m.State = state
+	return event
+}
@@ -0,0 +1,249 @@
+// Package mempool displays the transaction pool of the node.
+package mempool
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/algorand/go-algorand-sdk/v2/client/v2/common/models"
+	"github.com/algorand/go-algorand-sdk/v2/types"
+
+	"github.com/winder/algorand-navigator/messages"
+	"github.com/winder/algorand-navigator/tui/internal/bubbles/explorer"
+	"github.com/winder/algorand-navigator/tui/internal/style"
+	"github.com/winder/algorand-navigator/tui/internal/util"
+)
+
+const (
+	// maxPendingTxns limits the number of transactions fetched from the pool,
+	// the statistics are computed from this sample.
+	maxPendingTxns = 1000
+
+	refreshInterval = 1 * time.Second
+)
+
+// pendingMsg has the contents of the transaction pool.
+type pendingMsg struct {
+	total uint64
+	txns  []types.SignedTxn
+	err   error
+}
+
+// watchMsg has the pool status of the watched transaction.
+type watchMsg struct {
+	txid string
+	info models.PendingTransactionInfoResponse
+	err  error
+}
+
+// Model representing the mempool bubble.
+type Model struct {
+	style        *style.Styles
+	width        int
+	height       int
+	heightMargin int
+
+	// pool contents
+	total   uint64
+	txns    []types.SignedTxn
+	stats   poolStats
+	err     error
+	updated time.Time
+
+	prompt    textinput.Model
+	promptErr error
+	watch     *watchedTxn
+
+	requestor *messages.Requestor
+}
+
+// New creates the mempool Model.
+func New(styles *style.Styles, requestor *messages.Requestor, heightMargin int) Model {
+	prompt := textinput.New()
+	prompt.Prompt = "Watch txid: "
+	prompt.Placeholder = "transaction ID"
+	prompt.CharLimit = 64
+	return Model{
+		style:        styles,
+		heightMargin: heightMargin,
+		prompt:       prompt,
+		requestor:    requestor,
+	}
+}
+
+func (m Model) getPendingCmd() tea.Cmd {
+	return func() tea.Msg {
+		total, txns, err := m.requestor.Client.PendingTransactions().Max(maxPendingTxns).Do(context.Background())
+		return pendingMsg{
+			total: total,
+			txns:  txns,
+			err:   err,
+		}
+	}
+}
+
+func (m Model) getWatchCmd(txid string) tea.Cmd {
+	return func() tea.Msg {
+		info, _, err := m.requestor.Client.PendingTransactionInformation(txid).Do(context.Background())
+		return watchMsg{
+			txid: txid,
+			info: info,
+			err:  err,
+		}
+	}
+}
+
+// Init is part of the tea.Model interface.
+func (m Model) Init() tea.Cmd {
+	return m.getPendingCmd()
+}
+
+// CapturingInput is part of the util.InputCapturer interface.
+func (m Model) CapturingInput() bool {
+	return m.prompt.Focused()
+}
+
+// UpdateKeys is part of the util.KeyUpdater interface.
+func (m Model) UpdateKeys(active bool) {
+	util.AppKeys.WatchTxn.SetEnabled(active)
+}
+
+// Update is part of the tea.Model interface.
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+
+	case pendingMsg:
+		m.err = msg.err
+		if msg.err == nil {
+			m.total = msg.total
+			m.txns = msg.txns
+			m.stats = computeStats(msg.txns)
+			m.updated = time.Now()
+		}
+		return m, tea.Tick(refreshInterval, func(time.Time) tea.Msg {
+			return m.getPendingCmd()()
+		})
+
+	case watchMsg:
+		// ignore results for a transaction which is no longer watched.
+		if m.watch == nil || m.watch.id != msg.txid {
+			return m, nil
+		}
+		watch := m.watch.update(msg, time.Now())
+		m.watch = &watch
+		if watch.done() {
+			return m, nil
+		}
+		return m, tea.Tick(refreshInterval, func(time.Time) tea.Msg {
+			return m.getWatchCmd(msg.txid)()
+		})
+
+	case tea.KeyMsg:
+		if m.prompt.Focused() {
+			return m.updatePrompt(msg)
+		}
+		switch {
+		case key.Matches(msg, util.AppKeys.WatchTxn):
+			m.promptErr = nil
+			m.prompt.Reset()
+			return m, m.prompt.Focus()
+		case key.Matches(msg, util.AppKeys.Forward):
+			if m.watch != nil && m.watch.round != 0 {
+				round := m.watch.round
+				return m, func() tea.Msg {
+					return explorer.GotoRoundMsg{Round: round}
+				}
+			}
+		case key.Matches(msg, util.AppKeys.Back):
+			m.watch = nil
+		}
+	}
+
+	return m, nil
+}
+
+func (m Model) updatePrompt(msg tea.KeyMsg) (Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, util.AppKeys.Back):
+		m.prompt.Blur()
+		m.promptErr = nil
+		return m, nil
+	case key.Matches(msg, util.AppKeys.Forward):
+		txid := strings.ToUpper(strings.TrimSpace(m.prompt.Value()))
+		if err := validateTxID(txid); err != nil {
+			m.promptErr = err
+			return m, nil
+		}
+		m.prompt.Blur()
+		m.promptErr = nil
+		m.watch = &watchedTxn{id: txid, started: time.Now()}
+		return m, m.getWatchCmd(txid)
+	}
+
+	var cmd tea.Cmd
+	m.prompt, cmd = m.prompt.Update(msg)
+	return m, cmd
+}
+
+// View is part of the tea.Model interface.
+func (m Model) View() string {
+	bold := m.style.StatusBoldText
+	var b strings.Builder
+
+	switch {
+	case m.err != nil:
+		fmt.Fprintf(&b, "Error: %s\n", strings.ReplaceAll(m.err.Error(), "\n", " "))
+	case m.updated.IsZero():
+		b.WriteString("Loading transaction pool...\n")
+	default:
+		fmt.Fprintf(&b, "%s %d pending transactions", bold.Render("Pool size:"), m.total)
+		if m.total > uint64(len(m.txns)) {
+			fmt.Fprintf(&b, ", statistics use the first %d", len(m.txns))
+		}
+		fmt.Fprintf(&b, " (updated %s)\n", m.updated.Format("15:04:05"))
+	}
+	b.WriteString("\n")
+
+	fees := strings.TrimSuffix(m.stats.feeView(bold), "\n")
+	senders := strings.TrimSuffix(m.stats.senderView(bold), "\n")
+	columns := lipgloss.JoinHorizontal(lipgloss.Top, lipgloss.NewStyle().MarginRight(4).Render(fees), senders)
+	// stack the sections on narrow terminals.
+	if lipgloss.Width(columns)+m.style.Bottom.GetHorizontalFrameSize() > m.width {
+		columns = lipgloss.JoinVertical(lipgloss.Left, fees, "", senders)
+	}
+	b.WriteString(columns)
+	b.WriteString("\n\n")
+
+	b.WriteString(bold.Render("Watched transaction"))
+	b.WriteString("\n")
+	switch {
+	case m.prompt.Focused() && m.promptErr != nil:
+		fmt.Fprintf(&b, "%s  %s\n", m.prompt.View(), m.promptErr)
+	case m.prompt.Focused():
+		fmt.Fprintf(&b, "%s\n", m.prompt.View())
+	case m.watch == nil:
+		b.WriteString("Press w to watch a transaction ID.\n")
+	default:
+		b.WriteString(m.watch.view(time.Now()))
+	}
+
+	height := m.height - m.heightMargin - m.style.Bottom.GetVerticalBorderSize()
+	return m.style.Bottom.Copy().Height(max(0, height)).Render(b.String())
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,130 @@
+package mempool
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/algorand/go-algorand-sdk/v2/types"
+)
+
+const (
+	maxSenders = 10
+	barWidth   = 30
+)
+
+// feeBuckets are the upper bounds of the fee histogram in microalgos.
+var feeBuckets = []uint64{0, 1000, 2000, 10000, 100000, math.MaxUint64}
+
+// senderCount is the number of pending transactions of a sender.
+type senderCount struct {
+	sender types.Address
+	count  int
+}
+
+// poolStats summarizes the pending transactions.
+type poolStats struct {
+	// fees are sorted in ascending order.
+	fees    []uint64
+	buckets []int
+	senders []senderCount
+	// numSenders is the number of distinct senders.
+	numSenders int
+}
+
+func computeStats(txns []types.SignedTxn) poolStats {
+	stats := poolStats{
+		fees:    make([]uint64, 0, len(txns)),
+		buckets: make([]int, len(feeBuckets)),
+	}
+
+	bySender := make(map[types.Address]int)
+	for _, stxn := range txns {
+		fee := uint64(stxn.Txn.Fee)
+		stats.fees = append(stats.fees, fee)
+		stats.buckets[sort.Search(len(feeBuckets), func(i int) bool { return fee <= feeBuckets[i] })]++
+		bySender[stxn.Txn.Sender]++
+	}
+	sort.Slice(stats.fees, func(i, j int) bool { return stats.fees[i] < stats.fees[j] })
+
+	for sender, count := range bySender {
+		stats.senders = append(stats.senders, senderCount{sender, count})
+	}
+	sort.Slice(stats.senders, func(i, j int) bool {
+		if stats.senders[i].count != stats.senders[j].count {
+			return stats.senders[i].count > stats.senders[j].count
+		}
+		return stats.senders[i].sender.String() < stats.senders[j].sender.String()
+	})
+	stats.numSenders = len(stats.senders)
+	if len(stats.senders) > maxSenders {
+		stats.senders = stats.senders[:maxSenders]
+	}
+	return stats
+}
+
+// percentile uses the nearest rank method.
+func (s poolStats) percentile(p int) uint64 {
+	if len(s.fees) == 0 {
+		return 0
+	}
+	rank := (p*len(s.fees) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	return s.fees[rank-1]
+}
+
+func bucketLabel(i int) string {
+	switch {
+	case i == 0:
+		return "0 (pooled)"
+	case feeBuckets[i] == math.MaxUint64:
+		return fmt.Sprintf("> %f", types.MicroAlgos(feeBuckets[i-1]).ToAlgos())
+	}
+	return fmt.Sprintf("≤ %f", types.MicroAlgos(feeBuckets[i]).ToAlgos())
+}
+
+func (s poolStats) feeView(heading lipgloss.Style) string {
+	var b strings.Builder
+	b.WriteString(heading.Render("Fees (Algos)"))
+	b.WriteString("\n")
+	if len(s.fees) == 0 {
+		b.WriteString("no pending transactions\n")
+		return b.String()
+	}
+
+	fmt.Fprintf(&b, "%-12s %f\n", "min", types.MicroAlgos(s.fees[0]).ToAlgos())
+	fmt.Fprintf(&b, "%-12s %f\n", "median", types.MicroAlgos(s.percentile(50)).ToAlgos())
+	fmt.Fprintf(&b, "%-12s %f\n", "p90", types.MicroAlgos(s.percentile(90)).ToAlgos())
+	fmt.Fprintf(&b, "%-12s %f\n", "max", types.MicroAlgos(s.fees[len(s.fees)-1]).ToAlgos())
+	b.WriteString("\n")
+
+	largest := 0
+	for _, c := range s.buckets {
+		if c > largest {
+			largest = c
+		}
+	}
+	for i, c := range s.buckets {
+		bar := 0
+		if largest > 0 {
+			bar = (c*barWidth + largest - 1) / largest
+		}
+		fmt.Fprintf(&b, "%-12s %-*s %d\n", bucketLabel(i), barWidth, strings.Repeat("█", bar), c)
+	}
+	return b.String()
+}
+
+func (s poolStats) senderView(heading lipgloss.Style) string {
+	var b strings.Builder
+	b.WriteString(heading.Render(fmt.Sprintf("Senders (%d)", s.numSenders)))
+	b.WriteString("\n")
+	for _, sc := range s.senders {
+		fmt.Fprintf(&b, "%s %5d\n", sc.sender, sc.count)
+	}
+	return b.String()
+}
@@ -0,0 +1,95 @@
+package mempool
+
+import (
+	"encoding/base32"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/algorand/go-algorand-sdk/v2/types"
+)
+
+type watchStatus int
+
+const (
+	// unknownStatus is used until the node reports the transaction.
+	unknownStatus watchStatus = iota
+	pendingStatus
+	confirmedStatus
+	rejectedStatus
+)
+
+// watchedTxn follows a transaction from the pool into a block.
+type watchedTxn struct {
+	id      string
+	status  watchStatus
+	started time.Time
+
+	// pendingSince is when the transaction was first seen in the pool.
+	pendingSince time.Time
+	confirmedAt  time.Time
+	round        uint64
+	poolError    string
+	err          error
+}
+
+func validateTxID(txid string) error {
+	decoded, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(txid)
+	if err != nil || len(decoded) != len(types.Digest{}) {
+		return fmt.Errorf("'%s' is not a valid transaction ID", txid)
+	}
+	return nil
+}
+
+// update applies a pool status response.
+func (w watchedTxn) update(msg watchMsg, now time.Time) watchedTxn {
+	w.err = nil
+	switch {
+	case msg.err != nil && strings.HasPrefix(msg.err.Error(), "HTTP 404"):
+		// not submitted yet, or too old to be found.
+	case msg.err != nil:
+		w.err = msg.err
+	case msg.info.ConfirmedRound != 0:
+		w.status = confirmedStatus
+		w.round = msg.info.ConfirmedRound
+		w.confirmedAt = now
+	case msg.info.PoolError != "":
+		w.status = rejectedStatus
+		w.poolError = msg.info.PoolError
+	default:
+		if w.status != pendingStatus {
+			w.pendingSince = now
+		}
+		w.status = pendingStatus
+	}
+	return w
+}
+
+// done is true when the transaction can no longer change.
+func (w watchedTxn) done() bool {
+	return w.status == confirmedStatus || w.status == rejectedStatus
+}
+
+func (w watchedTxn) view(now time.Time) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s\n", w.id)
+	switch w.status {
+	case unknownStatus:
+		fmt.Fprintf(&b, "Not found in the pool or recent blocks, waiting for %s\n", now.Sub(w.started).Round(time.Second))
+	case pendingStatus:
+		fmt.Fprintf(&b, "Pending for %s\n", now.Sub(w.pendingSince).Round(time.Second))
+	case confirmedStatus:
+		if w.pendingSince.IsZero() {
+			fmt.Fprintf(&b, "Confirmed in round %d", w.round)
+		} else {
+			fmt.Fprintf(&b, "Confirmed in round %d after %s pending", w.round, w.confirmedAt.Sub(w.pendingSince).Round(time.Second))
+		}
+		b.WriteString(", press enter to view the block\n")
+	case rejectedStatus:
+		fmt.Fprintf(&b, "Removed from the pool: %s\n", w.poolError)
+	}
+	if w.err != nil {
+		fmt.Fprintf(&b, "Error: %s\n", strings.ReplaceAll(w.err.Error(), "\n", " "))
+	}
+	return b.String()
+}
@@ -0,0 +1,254 @@
+// Package metrics displays the Prometheus metrics served by algod.
+package metrics
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/winder/algorand-navigator/messages"
+	"github.com/winder/algorand-navigator/tui/internal/bubbles/health"
+	"github.com/winder/algorand-navigator/tui/internal/style"
+	"github.com/winder/algorand-navigator/tui/internal/util"
+)
+
+const (
+	// maxHistory is the number of samples kept for each panel.
+	maxHistory = 300
+
+	refreshInterval = 1 * time.Second
+
+	// errorInterval is used while the endpoint is unavailable.
+	errorInterval = 5 * time.Second
+
+	// maxEvents is the number of sync health events kept.
+	maxEvents = 100
+	// shownEvents is the number of sync health events displayed.
+	shownEvents = 5
+)
+
+// panel describes a metric on the dashboard.
+type panel struct {
+	title string
+	// names are added together.
+	names []string
+	// rate displays the change per second of a counter.
+	rate   bool
+	format func(float64) string
+}
+
+func formatCount(v float64) string {
+	return fmt.Sprintf("%.0f", v)
+}
+
+func formatByteRate(v float64) string {
+	units := []string{"B/s", "KB/s", "MB/s", "GB/s"}
+	i := 0
+	for v >= 1024 && i < len(units)-1 {
+		v /= 1024
+		i++
+	}
+	return fmt.Sprintf("%.1f %s", v, units[i])
+}
+
+var panels = []panel{
+	{
+		title:  "Peers",
+		names:  []string{"algod_network_incoming_connections", "algod_network_outgoing_connections"},
+		format: formatCount,
+	},
+	{
+		title:  "Transaction pool",
+		names:  []string{"algod_tx_pool_count"},
+		format: formatCount,
+	},
+	{
+		title:  "Ledger round",
+		names:  []string{"algod_ledger_round"},
+		format: formatCount,
+	},
+	{
+		title:  "Bytes in",
+		names:  []string{"algod_network_received_bytes_total"},
+		rate:   true,
+		format: formatByteRate,
+	},
+	{
+		title:  "Bytes out",
+		names:  []string{"algod_network_sent_bytes_total"},
+		rate:   true,
+		format: formatByteRate,
+	},
+}
+
+// history is the state of a panel.
+type history struct {
+	values []float64
+	// last is the previous counter value used to compute rates.
+	last  float64
+	found bool
+}
+
+// Model representing the metrics dashboard.
+type Model struct {
+	style        *style.Styles
+	width        int
+	height       int
+	heightMargin int
+
+	histories []history
+	lastTime  time.Time
+	err       error
+
+	// events are the sync health transitions, oldest first.
+	events []health.EventMsg
+
+	requestor *messages.Requestor
+}
+
+// New creates the metrics Model.
+func New(styles *style.Styles, requestor *messages.Requestor, heightMargin int) Model {
+	return Model{
+		style:        styles,
+		heightMargin: heightMargin,
+		histories:    make([]history, len(panels)),
+		requestor:    requestor,
+	}
+}
+
+// Init is part of the tea.Model interface.
+func (m Model) Init() tea.Cmd {
+	return m.requestor.GetMetricsCmd()
+}
+
+// addSamples records the latest value of each panel.
+func (m *Model) addSamples(samples []sample, now time.Time) {
+	elapsed := now.Sub(m.lastTime).Seconds()
+	histories := make([]history, len(panels))
+	for i, p := range panels {
+		h := m.histories[i]
+		value, found := sum(samples, p.names...)
+		switch {
+		case !found || math.IsNaN(value) || math.IsInf(value, 0):
+			found = false
+		case !p.rate:
+			h.values = append(h.values, value)
+		case h.found && elapsed > 0 && value >= h.last:
+			h.values = append(h.values, (value-h.last)/elapsed)
+		}
+		h.last, h.found = value, found
+		if len(h.values) > maxHistory {
+			h.values = h.values[len(h.values)-maxHistory:]
+		}
+		histories[i] = h
+	}
+	m.histories = histories
+	m.lastTime = now
+}
+
+// Update is part of the tea.Model interface.
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+
+	case health.EventMsg:
+		m.events = append(m.events, msg)
+		if len(m.events) > maxEvents {
+			m.events = m.events[len(m.events)-maxEvents:]
+		}
+
+	case messages.MetricsMsg:
+		interval := refreshInterval
+		m.err = msg.Err
+		if msg.Err == nil {
+			samples, err := parseMetrics(msg.Body)
+			if err != nil {
+				m.err = fmt.Errorf("unable to parse metrics: %w", err)
+			} else {
+				m.addSamples(samples, msg.Time)
+			}
+		}
+		if m.err != nil {
+			interval = errorInterval
+		}
+		return m, tea.Tick(interval, func(time.Time) tea.Msg {
+			return m.requestor.GetMetricsCmd()()
+		})
+	}
+
+	return m, nil
+}
+
+// View is part of the tea.Model interface.
+func (m Model) View() string {
+	bold := m.style.StatusBoldText
+	var b strings.Builder
+
+	switch {
+	case m.err != nil:
+		fmt.Fprintf(&b, "Error: %s\n", strings.ReplaceAll(m.err.Error(), "\n", " "))
+	case m.lastTime.IsZero():
+		b.WriteString("Loading metrics...\n")
+	default:
+		fmt.Fprintf(&b, "Updated %s\n", m.lastTime.Format("15:04:05"))
+	}
+
+	width := max(0, m.width-m.style.Bottom.GetHorizontalFrameSize())
+	for i, p := range panels {
+		// only the values which fit in the sparkline are summarized.
+		values := m.histories[i].values
+		if len(values) > width {
+			values = values[len(values)-width:]
+		}
+		current := "n/a"
+		if len(values) > 0 {
+			low, high := bounds(values)
+			current = fmt.Sprintf("%s (%s - %s)", p.format(values[len(values)-1]), p.format(low), p.format(high))
+		}
+		b.WriteString("\n")
+		fmt.Fprintf(&b, "%s %s\n", bold.Render(p.title+":"), current)
+		b.WriteString(util.Sparkline(values, width))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(bold.Render("Sync health:"))
+	b.WriteString("\n")
+	if len(m.events) == 0 {
+		b.WriteString("No changes yet.\n")
+	}
+	for i := len(m.events) - 1; i >= 0 && i >= len(m.events)-shownEvents; i-- {
+		event := m.events[i]
+		fmt.Fprintf(&b, "%s %s %s (was %s)\n",
+			event.Time.Format("15:04:05"), event.To.Style().Render(string(event.To)), event.Reason, event.From)
+	}
+
+	height := m.height - m.heightMargin - m.style.Bottom.GetVerticalBorderSize()
+	return m.style.Bottom.Copy().Height(max(0, height)).Render(b.String())
+}
+
+// bounds returns the smallest and largest value.
+func bounds(values []float64) (float64, float64) {
+	low, high := values[0], values[0]
+	for _, v := range values {
+		if v < low {
+			low = v
+		}
+		if v > high {
+			high = v
+		}
+	}
+	return low, high
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,121 @@
+package metrics
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// sample is a single value of a metric.
+type sample struct {
+	name   string
+	labels map[string]string
+	value  float64
+}
+
+// parseMetrics parses the Prometheus text exposition format. Comments, type
+// information and timestamps are ignored.
+func parseMetrics(text string) ([]sample, error) {
+	var samples []sample
+	for i, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		s, err := parseSample(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+		samples = append(samples, s)
+	}
+	return samples, nil
+}
+
+func parseSample(line string) (sample, error) {
+	s := sample{labels: make(map[string]string)}
+
+	end := strings.IndexAny(line, "{ \t")
+	if end <= 0 {
+		return s, fmt.Errorf("missing value for '%s'", line)
+	}
+	s.name = line[:end]
+	rest := line[end:]
+
+	if strings.HasPrefix(rest, "{") {
+		var err error
+		rest, err = parseLabels(rest[1:], s.labels)
+		if err != nil {
+			return s, fmt.Errorf("%s: %w", s.name, err)
+		}
+	}
+
+	// the value may be followed by a timestamp.
+	fields := strings.Fields(rest)
+	if len(fields) == 0 || len(fields) > 2 {
+		return s, fmt.Errorf("%s: expected a value and an optional timestamp", s.name)
+	}
+	value, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return s, fmt.Errorf("%s: invalid value '%s'", s.name, fields[0])
+	}
+	s.value = value
+	return s, nil
+}
+
+// parseLabels reads label pairs up to the closing brace and returns the rest
+// of the line.
+func parseLabels(text string, labels map[string]string) (string, error) {
+	for {
+		text = strings.TrimLeft(text, " \t,")
+		if strings.HasPrefix(text, "}") {
+			return text[1:], nil
+		}
+
+		eq := strings.Index(text, "=")
+		if eq <= 0 || len(text) < eq+2 || text[eq+1] != '"' {
+			return "", fmt.Errorf("malformed labels")
+		}
+		name := strings.TrimSpace(text[:eq])
+		text = text[eq+2:]
+
+		var value strings.Builder
+		closed := false
+		for i := 0; i < len(text) && !closed; i++ {
+			switch c := text[i]; {
+			case c == '\\' && i+1 < len(text):
+				i++
+				switch text[i] {
+				case 'n':
+					value.WriteByte('\n')
+				default:
+					value.WriteByte(text[i])
+				}
+			case c == '"':
+				closed = true
+				text = text[i+1:]
+			default:
+				value.WriteByte(c)
+			}
+		}
+		if !closed {
+			return "", fmt.Errorf("unterminated label value")
+		}
+		labels[name] = value.String()
+	}
+}
+
+// sum adds all samples of the metrics, the result is false if none of them
+// were found.
+func sum(samples []sample, names ...string) (float64, bool) {
+	var total float64
+	found := false
+	for _, s := range samples {
+		for _, name := range names {
+			if s.name == name {
+				total += s.value
+				found = true
+			}
+		}
+	}
+	return total, found
+}
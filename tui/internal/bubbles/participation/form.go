@@ -0,0 +1,184 @@
+package participation
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/algorand/go-algorand-sdk/v2/types"
+
+	"github.com/winder/algorand-navigator/tui/internal/util"
+)
+
+// defaultValidity is the number of rounds a new key is valid for unless a
+// last round is given, roughly three months.
+const defaultValidity = 3_000_000
+
+type formField int
+
+const (
+	addressField formField = iota
+	firstField
+	lastField
+	dilutionField
+	numFields
+)
+
+// keyRequest is a validated key generation form.
+type keyRequest struct {
+	address  string
+	first    uint64
+	last     uint64
+	dilution uint64
+}
+
+func (r keyRequest) String() string {
+	dilution := "default dilution"
+	if r.dilution != 0 {
+		dilution = fmt.Sprintf("dilution %d", r.dilution)
+	}
+	return fmt.Sprintf("%s valid for rounds %d to %d, %s", r.address, r.first, r.last, dilution)
+}
+
+// keyForm collects the arguments for generating a participation key.
+type keyForm struct {
+	inputs []textinput.Model
+	focus  formField
+	err    error
+}
+
+func newKeyForm() keyForm {
+	f := keyForm{inputs: make([]textinput.Model, numFields)}
+	labels := []string{"Address:   ", "First:     ", "Last:      ", "Dilution:  "}
+	for i := range f.inputs {
+		f.inputs[i] = textinput.New()
+		f.inputs[i].Prompt = labels[i]
+	}
+	f.inputs[addressField].CharLimit = 58
+	f.inputs[addressField].Placeholder = "account address"
+	f.inputs[dilutionField].Placeholder = "default"
+	return f
+}
+
+// open resets the form, the first round defaults to the current round.
+func (f keyForm) open(address string, round uint64) (keyForm, tea.Cmd) {
+	for i := range f.inputs {
+		f.inputs[i].Reset()
+		f.inputs[i].Blur()
+	}
+	f.inputs[addressField].SetValue(address)
+	f.inputs[firstField].Placeholder = strconv.FormatUint(round, 10)
+	f.inputs[lastField].Placeholder = fmt.Sprintf("first + %d", defaultValidity)
+	f.err = nil
+	f.focus = addressField
+	if address != "" {
+		f.focus = firstField
+	}
+	return f, f.inputs[f.focus].Focus()
+}
+
+func (f keyForm) focused() bool {
+	return f.inputs[f.focus].Focused()
+}
+
+func (f keyForm) close() keyForm {
+	f.inputs[f.focus].Blur()
+	return f
+}
+
+func (f keyForm) setFocus(field formField) (keyForm, tea.Cmd) {
+	f.inputs[f.focus].Blur()
+	f.focus = (field + numFields) % numFields
+	return f, f.inputs[f.focus].Focus()
+}
+
+// update handles a key, the request is returned once the last field is
+// submitted and every field is valid.
+func (f keyForm) update(msg tea.KeyMsg) (keyForm, *keyRequest, tea.Cmd) {
+	switch {
+	case key.Matches(msg, util.AppKeys.Section), msg.String() == "down":
+		f, cmd := f.setFocus(f.focus + 1)
+		return f, nil, cmd
+	case msg.String() == "shift+tab", msg.String() == "up":
+		f, cmd := f.setFocus(f.focus - 1)
+		return f, nil, cmd
+	case key.Matches(msg, util.AppKeys.Forward):
+		if f.focus != numFields-1 {
+			f, cmd := f.setFocus(f.focus + 1)
+			return f, nil, cmd
+		}
+		req, err := f.request()
+		f.err = err
+		if err != nil {
+			return f, nil, nil
+		}
+		return f, &req, nil
+	}
+
+	var cmd tea.Cmd
+	f.inputs[f.focus], cmd = f.inputs[f.focus].Update(msg)
+	return f, nil, cmd
+}
+
+// value returns a field or its placeholder when it is empty.
+func (f keyForm) value(field formField) string {
+	v := strings.TrimSpace(f.inputs[field].Value())
+	if v == "" && field == firstField {
+		return f.inputs[field].Placeholder
+	}
+	return v
+}
+
+func parseRound(name, value string) (uint64, error) {
+	round, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%s must be a positive number", name)
+	}
+	return round, nil
+}
+
+func (f keyForm) request() (keyRequest, error) {
+	var req keyRequest
+	var err error
+
+	req.address = strings.ToUpper(f.value(addressField))
+	if _, err = types.DecodeAddress(req.address); err != nil {
+		return req, fmt.Errorf("invalid address: %w", err)
+	}
+	if req.first, err = parseRound("first round", f.value(firstField)); err != nil {
+		return req, err
+	}
+	req.last = req.first + defaultValidity
+	if v := f.value(lastField); v != "" {
+		if req.last, err = parseRound("last round", v); err != nil {
+			return req, err
+		}
+	}
+	if req.last <= req.first {
+		return req, fmt.Errorf("last round must be after the first round")
+	}
+	if v := f.value(dilutionField); v != "" {
+		if req.dilution, err = parseRound("dilution", v); err != nil {
+			return req, err
+		}
+	}
+	return req, nil
+}
+
+func (f keyForm) view() string {
+	var b strings.Builder
+	for _, input := range f.inputs {
+		b.WriteString(input.View())
+		b.WriteString("\n")
+	}
+	if f.err != nil {
+		fmt.Fprintf(&b, "Error: %s\n", f.err)
+	} else {
+		b.WriteString("enter: next field, esc: cancel\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,423 @@
+// Package participation manages the participation keys installed on the node.
+package participation
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	table "github.com/calyptia/go-bubble-table"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/winder/algorand-navigator/messages"
+	"github.com/winder/algorand-navigator/tui/internal/style"
+	"github.com/winder/algorand-navigator/tui/internal/util"
+)
+
+const (
+	refreshInterval = 5 * time.Second
+
+	// expiryWarning is how long before the last key of an account expires
+	// that a warning is displayed.
+	expiryWarning = 7 * 24 * time.Hour
+
+	// defaultRoundTime is used until the round time has been measured.
+	defaultRoundTime = 4400 * time.Millisecond
+)
+
+// Hacked these in to workaround missing style options in table model
+var inactiveStyle = lipgloss.NewStyle()
+var activeStyle = inactiveStyle.Copy().Foreground(lipgloss.Color("#B083EA")).Bold(true)
+var warningStyle = inactiveStyle.Copy().Foreground(lipgloss.Color("#E3A322")).Bold(true)
+
+var keyTableHeader = []string{"  ADDRESS", "first", "last", "dilution", "last vote", "last proposal", "expires"}
+
+// keyItem is a row of the key table.
+type keyItem struct {
+	messages.ParticipationKey
+	expires string
+	warning bool
+}
+
+func shortAddress(addr string) string {
+	if len(addr) < 16 {
+		return addr
+	}
+	return addr[:8] + ".." + addr[len(addr)-4:]
+}
+
+func roundOrDash(round uint64) string {
+	if round == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%d", round)
+}
+
+// Render implements the Row interface to display a row of data.
+func (k keyItem) Render(w io.Writer, model table.Model, index int) {
+	var cursor string
+	if index == model.Cursor() {
+		cursor = "> "
+	} else {
+		cursor = "  "
+	}
+
+	row := fmt.Sprintf("%s%s\t%d\t%d\t%d\t%s\t%s\t%s", cursor, shortAddress(k.Address),
+		k.Key.VoteFirstValid, k.Key.VoteLastValid, k.Key.VoteKeyDilution,
+		roundOrDash(k.LastVote), roundOrDash(k.LastBlockProposal), k.expires)
+	switch {
+	case index == model.Cursor():
+		row = activeStyle.Render(row)
+	case k.warning:
+		row = warningStyle.Render(row)
+	default:
+		row = inactiveStyle.Render(row)
+	}
+	fmt.Fprintln(w, row)
+}
+
+// confirmation is an action waiting for the user to answer yes or no.
+type confirmation struct {
+	question string
+	cmd      tea.Cmd
+}
+
+// Model representing the participation bubble.
+type Model struct {
+	style        *style.Styles
+	width        int
+	height       int
+	heightMargin int
+
+	keys    []messages.ParticipationKey
+	err     error
+	updated time.Time
+
+	// round time calculation state
+	round      uint64
+	startRound uint64
+	startTime  time.Time
+	roundTime  time.Time
+
+	form    keyForm
+	confirm *confirmation
+	result  *messages.ParticipationResultMsg
+
+	table     table.Model
+	requestor *messages.Requestor
+}
+
+// New creates the participation Model.
+func New(styles *style.Styles, requestor *messages.Requestor, heightMargin int) Model {
+	t := table.New(keyTableHeader, 0, 0)
+	t.KeyMap.Up.SetKeys(append(t.KeyMap.Up.Keys(), "k")...)
+	t.KeyMap.Down.SetKeys(append(t.KeyMap.Down.Keys(), "j")...)
+	t.Styles.Title = styles.StatusBoldText
+	return Model{
+		style:        styles,
+		heightMargin: heightMargin,
+		form:         newKeyForm(),
+		table:        t,
+		requestor:    requestor,
+	}
+}
+
+// Init is part of the tea.Model interface.
+func (m Model) Init() tea.Cmd {
+	return m.requestor.GetParticipationKeysCmd()
+}
+
+// CapturingInput is part of the util.InputCapturer interface.
+func (m Model) CapturingInput() bool {
+	return m.form.focused() || m.confirm != nil
+}
+
+// UpdateKeys is part of the util.KeyUpdater interface.
+func (m Model) UpdateKeys(active bool) {
+	util.AppKeys.NewPartKey.SetEnabled(active)
+	util.AppKeys.DelPartKey.SetEnabled(active && len(m.keys) > 0)
+}
+
+// averageRoundTime is measured from the status updates.
+func (m Model) averageRoundTime() time.Duration {
+	if m.round <= m.startRound {
+		return defaultRoundTime
+	}
+	return m.roundTime.Sub(m.startTime) / time.Duration(m.round-m.startRound)
+}
+
+// untilRound estimates how long until a round is reached.
+func (m Model) untilRound(round uint64) time.Duration {
+	if round <= m.round {
+		return 0
+	}
+	return time.Duration(round-m.round) * m.averageRoundTime()
+}
+
+func formatDuration(d time.Duration) string {
+	hours := int(d.Hours())
+	switch {
+	case hours >= 24:
+		return fmt.Sprintf("%dd %dh", hours/24, hours%24)
+	case hours > 0:
+		return fmt.Sprintf("%dh %dm", hours, int(d.Minutes())%60)
+	}
+	return fmt.Sprintf("%dm", int(d.Minutes()))
+}
+
+// expiringAccounts returns the last valid round of the accounts whose keys
+// all expire soon.
+func (m Model) expiringAccounts() map[string]uint64 {
+	lastValid := make(map[string]uint64)
+	for _, k := range m.keys {
+		if k.Key.VoteLastValid > lastValid[k.Address] {
+			lastValid[k.Address] = k.Key.VoteLastValid
+		}
+	}
+	expiring := make(map[string]uint64)
+	for addr, last := range lastValid {
+		if m.round != 0 && m.untilRound(last) < expiryWarning {
+			expiring[addr] = last
+		}
+	}
+	return expiring
+}
+
+func (m *Model) updateTable() {
+	keys := make([]messages.ParticipationKey, len(m.keys))
+	copy(keys, m.keys)
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].Address != keys[j].Address {
+			return keys[i].Address < keys[j].Address
+		}
+		return keys[i].Key.VoteFirstValid < keys[j].Key.VoteFirstValid
+	})
+
+	expiring := m.expiringAccounts()
+	var rows []table.Row
+	for _, k := range keys {
+		item := keyItem{ParticipationKey: k, expires: "-"}
+		if m.round != 0 {
+			if k.Key.VoteLastValid <= m.round {
+				item.expires = "expired"
+			} else {
+				item.expires = formatDuration(m.untilRound(k.Key.VoteLastValid))
+			}
+		}
+		_, item.warning = expiring[k.Address]
+		rows = append(rows, item)
+	}
+	m.table.SetRows(rows)
+}
+
+// selected returns the key under the cursor.
+func (m Model) selected() (messages.ParticipationKey, bool) {
+	if len(m.keys) == 0 {
+		return messages.ParticipationKey{}, false
+	}
+	item, ok := m.table.SelectedRow().(keyItem)
+	return item.ParticipationKey, ok
+}
+
+// Update is part of the tea.Model interface.
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	m, cmd := m.update(msg)
+	// the table shares the space with the summary and action views.
+	m.setSize()
+	return m, cmd
+}
+
+func (m *Model) setSize() {
+	height := m.height - m.heightMargin - m.style.Bottom.GetVerticalFrameSize()
+	tableHeight := height - lipgloss.Height(m.wrap(m.summaryView())) - lipgloss.Height(m.wrap(m.actionView())) - 2
+	m.table.SetSize(m.contentWidth(), max(0, tableHeight))
+}
+
+func (m Model) contentWidth() int {
+	return max(0, m.width-m.style.Bottom.GetHorizontalFrameSize())
+}
+
+// wrap fits long lines, such as the addresses in a message, in the window.
+func (m Model) wrap(s string) string {
+	return lipgloss.NewStyle().Width(m.contentWidth()).Render(strings.TrimSuffix(s, "\n"))
+}
+
+func (m Model) update(msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+
+	case messages.StatusMsg:
+		if msg.Error != nil || msg.Status.LastRound <= m.round {
+			return m, nil
+		}
+		now := time.Now().Add(-time.Duration(msg.Status.TimeSinceLastRound))
+		if m.startRound == 0 {
+			m.startRound = msg.Status.LastRound
+			m.startTime = now
+		}
+		m.round = msg.Status.LastRound
+		m.roundTime = now
+		m.updateTable()
+		return m, nil
+
+	case messages.ParticipationKeysMsg:
+		m.err = msg.Err
+		if msg.Err == nil {
+			m.keys = msg.Keys
+			m.updated = time.Now()
+			m.updateTable()
+		}
+		return m, tea.Tick(refreshInterval, func(time.Time) tea.Msg {
+			return m.requestor.GetParticipationKeysCmd()()
+		})
+
+	case messages.ParticipationResultMsg:
+		m.result = &msg
+		return m, nil
+
+	case tea.KeyMsg:
+		return m.updateKeyMsg(msg)
+	}
+
+	return m, nil
+}
+
+func (m Model) updateKeyMsg(msg tea.KeyMsg) (Model, tea.Cmd) {
+	if m.confirm != nil {
+		confirm := m.confirm
+		m.confirm = nil
+		if msg.String() == "y" || msg.String() == "Y" {
+			m.result = nil
+			return m, confirm.cmd
+		}
+		return m, nil
+	}
+
+	if m.form.focused() {
+		if key.Matches(msg, util.AppKeys.Back) {
+			m.form = m.form.close()
+			return m, nil
+		}
+		form, req, cmd := m.form.update(msg)
+		m.form = form
+		if req != nil {
+			m.form = m.form.close()
+			r := *req
+			m.confirm = &confirmation{
+				question: fmt.Sprintf("Generate a participation key for %s?", r),
+				cmd:      m.requestor.GenerateParticipationKeyCmd(r.address, r.first, r.last, r.dilution),
+			}
+		}
+		return m, cmd
+	}
+
+	switch {
+	case key.Matches(msg, util.AppKeys.NewPartKey):
+		var address string
+		if k, ok := m.selected(); ok {
+			address = k.Address
+		}
+		var cmd tea.Cmd
+		m.form, cmd = m.form.open(address, m.round)
+		return m, cmd
+	case key.Matches(msg, util.AppKeys.DelPartKey):
+		if k, ok := m.selected(); ok {
+			m.confirm = &confirmation{
+				question: fmt.Sprintf("Delete participation key %s for %s, valid for rounds %d to %d?",
+					k.ID, k.Address, k.Key.VoteFirstValid, k.Key.VoteLastValid),
+				cmd: m.requestor.DeleteParticipationKeyCmd(k.ID),
+			}
+		}
+		return m, nil
+	case key.Matches(msg, util.AppKeys.Back):
+		m.result = nil
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.table, cmd = m.table.Update(msg)
+	return m, cmd
+}
+
+// summaryView describes the keys and any warnings above the table.
+func (m Model) summaryView() string {
+	var lines []string
+	switch {
+	case m.err != nil:
+		lines = append(lines, fmt.Sprintf("Error: %s", m.err))
+	case m.updated.IsZero():
+		lines = append(lines, "Loading participation keys...")
+	default:
+		lines = append(lines, fmt.Sprintf("%d participation keys (updated %s)", len(m.keys), m.updated.Format("15:04:05")))
+	}
+
+	expiring := m.expiringAccounts()
+	addresses := make([]string, 0, len(expiring))
+	for addr := range expiring {
+		addresses = append(addresses, addr)
+	}
+	sort.Strings(addresses)
+	for _, addr := range addresses {
+		last := expiring[addr]
+		if last <= m.round {
+			lines = append(lines, warningStyle.Render(fmt.Sprintf("Warning: the keys for %s expired at round %d", addr, last)))
+		} else {
+			lines = append(lines, warningStyle.Render(fmt.Sprintf("Warning: the keys for %s expire at round %d, in about %s",
+				addr, last, formatDuration(m.untilRound(last)))))
+		}
+	}
+
+	if m.result != nil {
+		if m.result.Err != nil {
+			lines = append(lines, fmt.Sprintf("Unable to %s key: %s", m.result.Action, m.result.Err))
+		}
+		if m.result.Output != "" {
+			lines = append(lines, m.result.Output)
+		}
+	}
+	for i := range lines {
+		lines[i] = strings.ReplaceAll(lines[i], "\n", " ")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// actionView is the form, the confirmation prompt or the selected key.
+func (m Model) actionView() string {
+	bold := m.style.StatusBoldText
+	switch {
+	case m.confirm != nil:
+		return fmt.Sprintf("%s\n%s\n", m.confirm.question, bold.Render("Press y to confirm, any other key to cancel."))
+	case m.form.focused():
+		return bold.Render("New participation key") + "\n" + m.form.view()
+	}
+	k, ok := m.selected()
+	if !ok {
+		return "Press n to generate a participation key.\n"
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %s\n", bold.Render("ID:"), k.ID)
+	fmt.Fprintf(&b, "%s %s\n", bold.Render("Address:"), k.Address)
+	fmt.Fprintf(&b, "%s %s - %s\n", bold.Render("Effective rounds:"), roundOrDash(k.EffectiveFirstValid), roundOrDash(k.EffectiveLastValid))
+	return b.String()
+}
+
+// View is part of the tea.Model interface.
+func (m Model) View() string {
+	content := lipgloss.JoinVertical(0, m.wrap(m.summaryView()), "", m.table.View(), "", m.wrap(m.actionView()))
+	height := m.height - m.heightMargin - m.style.Bottom.GetVerticalBorderSize()
+	return m.style.Bottom.Copy().Height(max(0, height)).Render(content)
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
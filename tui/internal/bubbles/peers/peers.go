@@ -0,0 +1,243 @@
+// Package peers displays the network connections of the node.
+package peers
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	table "github.com/calyptia/go-bubble-table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/winder/algorand-navigator/messages"
+	"github.com/winder/algorand-navigator/tui/internal/style"
+)
+
+const refreshInterval = 1 * time.Second
+
+// Hacked these in to workaround missing style options in table model
+var inactiveStyle = lipgloss.NewStyle()
+var activeStyle = inactiveStyle.Copy().Foreground(lipgloss.Color("#B083EA")).Bold(true)
+
+var peerTableHeader = []string{"  ADDRESS", "direction", "relay", "archival", "age"}
+
+// peerItem is a row of the peer table.
+type peerItem struct {
+	messages.Peer
+	relay    bool
+	archival bool
+	age      string
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "-"
+}
+
+// Render implements the Row interface to display a row of data.
+func (p peerItem) Render(w io.Writer, model table.Model, index int) {
+	var cursor string
+	if index == model.Cursor() {
+		cursor = "> "
+	} else {
+		cursor = "  "
+	}
+
+	direction := "outgoing"
+	if p.Incoming {
+		direction = "incoming"
+	}
+	row := fmt.Sprintf("%s%s\t%s\t%s\t%s\t%s", cursor, p.Address, direction, yesNo(p.relay), yesNo(p.archival), p.age)
+	if index == model.Cursor() {
+		row = activeStyle.Render(row)
+	} else {
+		row = inactiveStyle.Render(row)
+	}
+	fmt.Fprintln(w, row)
+}
+
+// Model representing the peers bubble.
+type Model struct {
+	style        *style.Styles
+	width        int
+	height       int
+	heightMargin int
+
+	peers          []messages.Peer
+	phonebook      bool
+	fallbackReason error
+	err            error
+
+	// firstSeen is when each connection was first observed, connections
+	// which existed at startup are older than shown.
+	firstSeen map[string]time.Time
+	started   time.Time
+
+	network   string
+	relays    map[string]bool
+	archivers map[string]bool
+	relaysErr error
+
+	table     table.Model
+	requestor *messages.Requestor
+}
+
+// New creates the peers Model.
+func New(styles *style.Styles, requestor *messages.Requestor, heightMargin int) Model {
+	t := table.New(peerTableHeader, 0, 0)
+	t.KeyMap.Up.SetKeys(append(t.KeyMap.Up.Keys(), "k")...)
+	t.KeyMap.Down.SetKeys(append(t.KeyMap.Down.Keys(), "j")...)
+	t.Styles.Title = styles.StatusBoldText
+	return Model{
+		style:        styles,
+		heightMargin: heightMargin,
+		firstSeen:    make(map[string]time.Time),
+		table:        t,
+		requestor:    requestor,
+	}
+}
+
+// Init is part of the tea.Model interface.
+func (m Model) Init() tea.Cmd {
+	return m.requestor.GetPeersCmd()
+}
+
+func (m *Model) setSize(width, height int) {
+	m.width = width
+	m.height = height
+	tableHeight := height - m.heightMargin - m.style.Bottom.GetVerticalFrameSize() - lipgloss.Height(m.summaryView())
+	m.table.SetSize(width-m.style.Bottom.GetHorizontalFrameSize(), tableHeight)
+}
+
+// updateTable rebuilds the rows, connections are tracked from the first
+// time they are seen.
+func (m *Model) updateTable(now time.Time) {
+	if m.started.IsZero() {
+		m.started = now
+	}
+
+	current := make(map[string]time.Time, len(m.peers))
+	for _, p := range m.peers {
+		seen, ok := m.firstSeen[p.Address]
+		if !ok {
+			seen = now
+		}
+		current[p.Address] = seen
+	}
+	if !m.phonebook {
+		m.firstSeen = current
+	}
+
+	peers := make([]messages.Peer, len(m.peers))
+	copy(peers, m.peers)
+	sort.Slice(peers, func(i, j int) bool {
+		return peers[i].Address < peers[j].Address
+	})
+
+	var rows []table.Row
+	for _, p := range peers {
+		item := peerItem{
+			Peer:     p,
+			relay:    m.relays[p.Address],
+			archival: m.archivers[p.Address],
+			age:      "-",
+		}
+		if !m.phonebook {
+			seen := m.firstSeen[p.Address]
+			item.age = now.Sub(seen).Round(time.Second).String()
+			if seen.Equal(m.started) {
+				item.age = "> " + item.age
+			}
+		}
+		rows = append(rows, item)
+	}
+	m.table.SetRows(rows)
+}
+
+// Update is part of the tea.Model interface.
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmds []tea.Cmd
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.setSize(msg.Width, msg.Height)
+		return m, nil
+
+	case messages.NetworkMsg:
+		if msg.Err != nil || m.network != "" {
+			return m, nil
+		}
+		m.network = strings.Split(msg.GenesisID, "-")[0]
+		return m, m.requestor.GetRelaysCmd(m.network)
+
+	case messages.RelaysMsg:
+		m.relays = msg.Relays
+		m.archivers = msg.Archivers
+		m.relaysErr = msg.Err
+		m.updateTable(time.Now())
+		return m, nil
+
+	case messages.PeersMsg:
+		m.err = msg.Err
+		if msg.Err == nil {
+			m.peers = msg.Peers
+			m.phonebook = msg.Phonebook
+			m.fallbackReason = msg.FallbackReason
+			m.updateTable(time.Now())
+			m.setSize(m.width, m.height)
+		}
+		return m, tea.Tick(refreshInterval, func(time.Time) tea.Msg {
+			return m.requestor.GetPeersCmd()()
+		})
+	}
+
+	var cmd tea.Cmd
+	m.table, cmd = m.table.Update(msg)
+	cmds = append(cmds, cmd)
+	return m, tea.Batch(cmds...)
+}
+
+// summaryView describes the peer source above the table.
+func (m Model) summaryView() string {
+	var lines []string
+	switch {
+	case m.err != nil:
+		lines = append(lines, fmt.Sprintf("Error: %s", m.err))
+	case m.phonebook:
+		lines = append(lines, fmt.Sprintf("Phonebook: %d entries", len(m.peers)))
+		if m.fallbackReason != nil {
+			lines = append(lines, fmt.Sprintf("Connections are not available: %s", m.fallbackReason))
+		}
+	case m.started.IsZero():
+		lines = append(lines, "Loading connections...")
+	default:
+		incoming := 0
+		relays := 0
+		for _, p := range m.peers {
+			if p.Incoming {
+				incoming++
+			}
+			if m.relays[p.Address] {
+				relays++
+			}
+		}
+		lines = append(lines, fmt.Sprintf("%d connections, %d incoming, %d outgoing, %d to relays",
+			len(m.peers), incoming, len(m.peers)-incoming, relays))
+	}
+	if m.relaysErr != nil {
+		lines = append(lines, fmt.Sprintf("Relay flags are not available: %s", m.relaysErr))
+	}
+	for i := range lines {
+		lines[i] = strings.ReplaceAll(lines[i], "\n", " ")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// View is part of the tea.Model interface.
+func (m Model) View() string {
+	return m.style.Bottom.Render(lipgloss.JoinVertical(0, m.summaryView(), m.table.View()))
+}
@@ -0,0 +1,218 @@
+// Package rounds displays the round time distribution and the proposers of the
+// latest blocks.
+package rounds
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/algorand/go-algorand-sdk/v2/types"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/winder/algorand-navigator/tui/internal/bubbles/accounts"
+	"github.com/winder/algorand-navigator/tui/internal/bubbles/explorer"
+	"github.com/winder/algorand-navigator/tui/internal/style"
+)
+
+const (
+	// maxProposers is the number of proposers listed.
+	maxProposers = 10
+
+	// columnGap separates the histogram from the proposers.
+	columnGap = 4
+)
+
+var (
+	barStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("#B083EA"))
+	watchedStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#2AB56F")).Bold(true)
+)
+
+// Model representing the round statistics.
+type Model struct {
+	style        *style.Styles
+	width        int
+	height       int
+	heightMargin int
+
+	window Window
+
+	watched []types.Address
+	labels  map[types.Address]string
+}
+
+// New creates the rounds Model.
+func New(styles *style.Styles, heightMargin int) Model {
+	return Model{
+		style:        styles,
+		heightMargin: heightMargin,
+		window:       NewWindow(),
+		labels:       make(map[types.Address]string),
+	}
+}
+
+// Init is part of the tea.Model interface.
+func (m Model) Init() tea.Cmd {
+	return nil
+}
+
+// Update is part of the tea.Model interface.
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+
+	case accounts.WatchListMsg:
+		m.watched = msg.Addresses
+		m.labels = msg.Labels
+
+	case explorer.BlocksMsg:
+		if msg.Err == nil {
+			m.window.Add(msg.Blocks)
+		}
+	}
+	return m, nil
+}
+
+// View is part of the tea.Model interface.
+func (m Model) View() string {
+	var content string
+	if len(m.window.rounds) == 0 {
+		content = "Waiting for blocks..."
+	} else {
+		width := max(0, m.width-m.style.Bottom.GetHorizontalFrameSize())
+		left := (width - columnGap) / 2
+		content = lipgloss.JoinHorizontal(lipgloss.Top,
+			lipgloss.NewStyle().Width(left+columnGap).Render(m.roundTimesView(left)),
+			m.proposersView())
+	}
+
+	height := m.height - m.heightMargin - m.style.Bottom.GetVerticalBorderSize()
+	return m.style.Bottom.Copy().Height(max(0, height)).Render(content)
+}
+
+// roundTimesView renders the round time summary and histogram.
+func (m Model) roundTimesView(width int) string {
+	bold := m.style.StatusBoldText
+	var b strings.Builder
+	b.WriteString(bold.Render("Round times:"))
+	fmt.Fprintf(&b, " rounds %d - %d\n", m.window.first(), m.window.latest)
+
+	durations := m.window.Durations()
+	if len(durations) == 0 {
+		b.WriteString("Waiting for consecutive rounds...\n")
+		return b.String()
+	}
+	fmt.Fprintf(&b, "p50 %s  p95 %s  max %s  mean %s\n\n",
+		percentile(durations, 0.5),
+		percentile(durations, 0.95),
+		durations[len(durations)-1],
+		mean(durations).Round(10*time.Millisecond))
+
+	buckets := histogram(durations)
+	highest := 0
+	for _, bkt := range buckets {
+		highest = max(highest, bkt.count)
+	}
+	countWidth := len(fmt.Sprint(len(durations)))
+	// the label and the percentage take 12 columns besides the count.
+	barWidth := max(1, width-countWidth-12)
+	for _, bkt := range buckets {
+		label := fmt.Sprintf("%ds", bkt.seconds)
+		if bkt.overflow {
+			label = "≥" + label
+		}
+		bar := 0
+		if bkt.count > 0 {
+			bar = max(1, bkt.count*barWidth/highest)
+		}
+		fmt.Fprintf(&b, "%5s %s%s %*d %3.0f%%\n",
+			label,
+			barStyle.Render(strings.Repeat("█", bar)),
+			strings.Repeat(" ", barWidth-bar),
+			countWidth, bkt.count,
+			100*float64(bkt.count)/float64(len(durations)))
+	}
+	fmt.Fprintf(&b, "\n%d round times, block timestamps are in seconds.\n", len(durations))
+	return b.String()
+}
+
+// name is the label of an address, or the short address.
+func (m Model) name(addr types.Address) string {
+	s := addr.String()
+	short := s[:6] + "…" + s[len(s)-4:]
+	if label := m.labels[addr]; label != "" {
+		return fmt.Sprintf("%s (%s)", label, short)
+	}
+	return short
+}
+
+// proposersView renders the most frequent proposers and the proposals of the
+// watched accounts.
+func (m Model) proposersView() string {
+	bold := m.style.StatusBoldText
+	counts, total := m.window.proposers()
+	byAddress := make(map[types.Address]proposerCount, len(counts))
+	for _, c := range counts {
+		byAddress[c.address] = c
+	}
+	watched := make(map[types.Address]bool, len(m.watched))
+	for _, addr := range m.watched {
+		watched[addr] = true
+	}
+
+	var b strings.Builder
+	b.WriteString(bold.Render("Proposers:"))
+	fmt.Fprintf(&b, " %d blocks, %d accounts\n", total, len(counts))
+	if total == 0 {
+		b.WriteString("The block certificates are not available.\n")
+	}
+	names := make([]string, 0, maxProposers)
+	for i := 0; i < len(counts) && i < maxProposers; i++ {
+		names = append(names, m.name(counts[i].address))
+	}
+	nameWidth := 0
+	for _, n := range names {
+		nameWidth = max(nameWidth, lipgloss.Width(n))
+	}
+	for i, n := range names {
+		c := counts[i]
+		row := fmt.Sprintf("%s%s %4d %5.1f%%",
+			n, strings.Repeat(" ", nameWidth-lipgloss.Width(n)),
+			c.count, 100*float64(c.count)/float64(total))
+		if watched[c.address] {
+			row = watchedStyle.Render(row)
+		}
+		b.WriteString(row)
+		b.WriteString("\n")
+	}
+	if len(counts) > maxProposers {
+		fmt.Fprintf(&b, "and %d more\n", len(counts)-maxProposers)
+	}
+
+	b.WriteString("\n")
+	b.WriteString(bold.Render("Watched accounts:"))
+	b.WriteString("\n")
+	if len(m.watched) == 0 {
+		b.WriteString("No watched accounts.\n")
+	}
+	for _, addr := range m.watched {
+		c, ok := byAddress[addr]
+		if !ok {
+			fmt.Fprintf(&b, "%s did not propose in the last %d blocks\n", m.name(addr), len(m.window.rounds))
+			continue
+		}
+		b.WriteString(watchedStyle.Render(fmt.Sprintf("%s proposed %d blocks, last in round %d", m.name(addr), c.count, c.last)))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,195 @@
+package rounds
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/algorand/go-algorand-sdk/v2/types"
+
+	"github.com/winder/algorand-navigator/tui/internal/bubbles/explorer"
+)
+
+const (
+	// windowSize is the number of rounds used for the statistics.
+	windowSize = 1000
+
+	// maxBuckets is the number of histogram rows, the last one holds every
+	// longer round.
+	maxBuckets = 10
+)
+
+// roundInfo is what is kept of each block in the window.
+type roundInfo struct {
+	timestamp   int64
+	proposer    types.Address
+	hasProposer bool
+}
+
+// Window holds the timestamp and proposer of the latest rounds.
+type Window struct {
+	rounds map[uint64]roundInfo
+	latest uint64
+}
+
+// NewWindow creates an empty Window.
+func NewWindow() Window {
+	return Window{rounds: make(map[uint64]roundInfo)}
+}
+
+// first is the oldest round which fits in the window.
+func (w Window) first() uint64 {
+	if w.latest < windowSize {
+		return 0
+	}
+	return w.latest - windowSize + 1
+}
+
+// Add records the blocks, rounds older than the window are ignored.
+func (w *Window) Add(blocks []explorer.BlockItem) {
+	for _, blk := range blocks {
+		if blk.Round > w.latest {
+			w.latest = blk.Round
+		}
+	}
+	first := w.first()
+	for _, blk := range blocks {
+		if blk.Round < first {
+			continue
+		}
+		info := roundInfo{timestamp: blk.Block.Block.TimeStamp}
+		info.proposer, info.hasProposer = blk.Proposer()
+		w.rounds[blk.Round] = info
+	}
+	for round := range w.rounds {
+		if round < first {
+			delete(w.rounds, round)
+		}
+	}
+}
+
+// Durations returns the time between consecutive rounds, sorted. Block
+// timestamps have a one second resolution.
+func (w Window) Durations() []time.Duration {
+	var durations []time.Duration
+	for round, info := range w.rounds {
+		previous, ok := w.rounds[round-1]
+		if round == 0 || !ok || info.timestamp < previous.timestamp {
+			continue
+		}
+		durations = append(durations, time.Duration(info.timestamp-previous.timestamp)*time.Second)
+	}
+	sort.Slice(durations, func(i, j int) bool {
+		return durations[i] < durations[j]
+	})
+	return durations
+}
+
+// percentile uses the nearest rank method on sorted durations.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p*float64(len(sorted)))) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	return sorted[rank]
+}
+
+// Mean returns the average round time, ok is false until two consecutive
+// rounds are known.
+func (w Window) Mean() (d time.Duration, ok bool) {
+	durations := w.Durations()
+	if len(durations) == 0 {
+		return 0, false
+	}
+	return mean(durations), true
+}
+
+func mean(durations []time.Duration) time.Duration {
+	if len(durations) == 0 {
+		return 0
+	}
+	var total time.Duration
+	for _, d := range durations {
+		total += d
+	}
+	return total / time.Duration(len(durations))
+}
+
+// bucket is a row of the histogram.
+type bucket struct {
+	seconds int64
+	// overflow is set for the last bucket, which also counts longer rounds.
+	overflow bool
+	count    int
+}
+
+// histogram counts the sorted durations per second, starting from the
+// shortest.
+func histogram(sorted []time.Duration) []bucket {
+	if len(sorted) == 0 {
+		return nil
+	}
+	low := int64(sorted[0] / time.Second)
+	high := int64(sorted[len(sorted)-1] / time.Second)
+	n := int(high-low) + 1
+	if n > maxBuckets {
+		n = maxBuckets
+	}
+
+	buckets := make([]bucket, n)
+	for i := range buckets {
+		buckets[i].seconds = low + int64(i)
+	}
+	buckets[n-1].overflow = high > buckets[n-1].seconds
+	for _, d := range sorted {
+		i := int(int64(d/time.Second) - low)
+		if i >= n {
+			i = n - 1
+		}
+		buckets[i].count++
+	}
+	return buckets
+}
+
+// proposerCount is the number of blocks an address proposed in the window.
+type proposerCount struct {
+	address types.Address
+	count   int
+	last    uint64
+}
+
+// proposers counts the proposers of the window, most blocks first. total is
+// the number of blocks with a known proposer.
+func (w Window) proposers() (counts []proposerCount, total int) {
+	byAddress := make(map[types.Address]*proposerCount)
+	for round, info := range w.rounds {
+		if !info.hasProposer {
+			continue
+		}
+		total++
+		c, ok := byAddress[info.proposer]
+		if !ok {
+			c = &proposerCount{address: info.proposer}
+			byAddress[info.proposer] = c
+		}
+		c.count++
+		if round > c.last {
+			c.last = round
+		}
+	}
+
+	counts = make([]proposerCount, 0, len(byAddress))
+	for _, c := range byAddress {
+		counts = append(counts, *c)
+	}
+	sort.Slice(counts, func(i, j int) bool {
+		if counts[i].count != counts[j].count {
+			return counts[i].count > counts[j].count
+		}
+		return counts[i].last > counts[j].last
+	})
+	return counts, total
+}
@@ -0,0 +1,229 @@
+package status
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/algorand/go-algorand-sdk/v2/client/v2/common/models"
+)
+
+// phase is a step of a fast catchup.
+type phase int
+
+const (
+	downloadAccountsPhase phase = iota
+	processAccountsPhase
+	acquireBlocksPhase
+	numPhases
+)
+
+var phaseNames = [numPhases]string{"Downloading accounts", "Processing accounts", "Downloading blocks"}
+
+// phaseSummaryNames are used in the summary of a completed catchup.
+var phaseSummaryNames = [numPhases]string{"accounts", "processing", "blocks"}
+
+const (
+	// rateWindow is how far back samples are used to compute the rate.
+	rateWindow = 10 * time.Second
+	// rateInterval is how often the rate is added to the sparkline.
+	rateInterval = time.Second
+	// maxRates is the number of rates kept for the sparkline.
+	maxRates = 30
+)
+
+// sample is the progress of a phase at some time.
+type sample struct {
+	time time.Time
+	done uint64
+}
+
+// phaseProgress tracks the throughput of a catchup phase.
+type phaseProgress struct {
+	done  uint64
+	total uint64
+	start time.Time
+	end   time.Time
+
+	samples  []sample
+	rates    []float64
+	lastRate time.Time
+}
+
+func (p phaseProgress) started() bool {
+	return !p.start.IsZero()
+}
+
+func (p phaseProgress) finished() bool {
+	return !p.end.IsZero()
+}
+
+func (p phaseProgress) fraction() float64 {
+	switch {
+	case p.finished():
+		return 1
+	case p.total == 0:
+		return 0
+	}
+	return float64(p.done) / float64(p.total)
+}
+
+// update records the progress of the phase.
+func (p *phaseProgress) update(done, total uint64, now time.Time) {
+	if p.finished() {
+		return
+	}
+	p.done, p.total = done, total
+	if done > 0 && !p.started() {
+		p.start = now
+	}
+	if !p.started() {
+		return
+	}
+
+	p.samples = append(p.samples, sample{time: now, done: done})
+	for len(p.samples) > 1 && now.Sub(p.samples[0].time) > rateWindow {
+		p.samples = p.samples[1:]
+	}
+	if now.Sub(p.lastRate) >= rateInterval {
+		p.lastRate = now
+		p.rates = append(p.rates, p.rate())
+		if len(p.rates) > maxRates {
+			p.rates = p.rates[len(p.rates)-maxRates:]
+		}
+	}
+	if total > 0 && done >= total {
+		p.finish(now)
+	}
+}
+
+// finish ends the phase, phases may end without reaching the total when the
+// node moves on to the next one.
+func (p *phaseProgress) finish(now time.Time) {
+	if p.finished() {
+		return
+	}
+	if !p.started() {
+		p.start = now
+	}
+	p.end = now
+	p.done = p.total
+}
+
+// rate is the number of items per second over the rate window.
+func (p phaseProgress) rate() float64 {
+	if len(p.samples) < 2 {
+		return 0
+	}
+	first, last := p.samples[0], p.samples[len(p.samples)-1]
+	elapsed := last.time.Sub(first.time).Seconds()
+	if elapsed <= 0 || last.done < first.done {
+		return 0
+	}
+	return float64(last.done-first.done) / elapsed
+}
+
+// eta is the remaining time at the current rate, ok is false when it isn't
+// known.
+func (p phaseProgress) eta() (time.Duration, bool) {
+	rate := p.rate()
+	if rate <= 0 || p.total < p.done {
+		return 0, false
+	}
+	return time.Duration(float64(p.total-p.done) / rate * float64(time.Second)), true
+}
+
+func (p phaseProgress) duration() time.Duration {
+	return p.end.Sub(p.start)
+}
+
+// catchupProgress tracks a fast catchup to a catchpoint.
+type catchupProgress struct {
+	catchpoint string
+	round      uint64
+	start      time.Time
+	end        time.Time
+	phases     [numPhases]phaseProgress
+}
+
+// catchpointRound returns the round of a catchpoint label.
+func catchpointRound(catchpoint string) uint64 {
+	round, _ := strconv.ParseUint(strings.Split(catchpoint, "#")[0], 10, 64)
+	return round
+}
+
+// updateCatchup follows a fast catchup in the node status. When the catchup
+// completes the finished progress is returned as the summary.
+func updateCatchup(c *catchupProgress, status models.NodeStatus, now time.Time) (summary *catchupProgress) {
+	if status.Catchpoint == "" {
+		// the node reached the catchpoint round unless it was aborted.
+		if c.catchpoint != "" && status.LastRound >= c.round {
+			for i := range c.phases {
+				c.phases[i].finish(now)
+			}
+			c.end = now
+			done := *c
+			summary = &done
+		}
+		*c = catchupProgress{}
+		return summary
+	}
+
+	if c.catchpoint != status.Catchpoint {
+		*c = catchupProgress{
+			catchpoint: status.Catchpoint,
+			round:      catchpointRound(status.Catchpoint),
+			start:      now,
+		}
+	}
+
+	accounts := &c.phases[downloadAccountsPhase]
+	verified := &c.phases[processAccountsPhase]
+	blocks := &c.phases[acquireBlocksPhase]
+	accounts.update(status.CatchpointProcessedAccounts, status.CatchpointTotalAccounts, now)
+	verified.update(status.CatchpointVerifiedAccounts, status.CatchpointTotalAccounts, now)
+	if status.CatchpointTotalBlocks > 0 {
+		// the accounts are done once the node asks for blocks.
+		accounts.finish(now)
+		verified.finish(now)
+	}
+	blocks.update(status.CatchpointAcquiredBlocks, status.CatchpointTotalBlocks, now)
+	return nil
+}
+
+// formatRate formats items per second compactly.
+func formatRate(rate float64) string {
+	switch {
+	case rate >= 1000000:
+		return fmt.Sprintf("%.1fM/s", rate/1000000)
+	case rate >= 1000:
+		return fmt.Sprintf("%.1fk/s", rate/1000)
+	}
+	return fmt.Sprintf("%.0f/s", rate)
+}
+
+// phaseInfo describes the rate and ETA of a phase, or how long it took.
+func phaseInfo(p phaseProgress) string {
+	switch {
+	case p.finished():
+		return fmt.Sprintf("done in %s", p.duration().Round(time.Second))
+	case !p.started():
+		return ""
+	}
+	eta := "-"
+	if d, ok := p.eta(); ok {
+		eta = d.Round(time.Second).String()
+	}
+	return fmt.Sprintf("%s %s", formatRate(p.rate()), eta)
+}
+
+// summary describes how long each phase of a completed catchup took.
+func (c catchupProgress) summary() string {
+	parts := make([]string, 0, numPhases)
+	for i, p := range c.phases {
+		parts = append(parts, fmt.Sprintf("%s %s", phaseSummaryNames[i], p.duration().Round(time.Second)))
+	}
+	return fmt.Sprintf("Last fast catchup took %s: %s.",
+		c.end.Sub(c.start).Round(time.Second), strings.Join(parts, ", "))
+}
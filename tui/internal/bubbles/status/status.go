@@ -16,17 +16,30 @@ import (
 
 	"github.com/winder/algorand-navigator/messages"
 	"github.com/winder/algorand-navigator/tui/internal/bubbles/explorer"
+	"github.com/winder/algorand-navigator/tui/internal/bubbles/rounds"
+	"github.com/winder/algorand-navigator/tui/internal/bubbles/upgrade"
 	"github.com/winder/algorand-navigator/tui/internal/style"
 	"github.com/winder/algorand-navigator/tui/internal/util"
 )
 
 const roundTo = time.Second / 10
 
-// consensus constants, in theory these could be modified by a consensus upgrade.
-const (
-	upgradeVoteRounds = 10000
-	upgradeThreshold  = 9000
-)
+// progressWidth leaves room for the rate next to the catchup progress bars.
+const progressWidth = 16
+
+// rateSparkWidth is the width of the catchup rate sparklines.
+const rateSparkWidth = 8
+
+// bannerDuration is how long the result of a fast catchup request is displayed.
+const bannerDuration = 30 * time.Second
+
+var successStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#2AB56F")).Bold(true)
+var errorStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#E3A322")).Bold(true)
+
+// bannerExpiredMsg hides a banner once it has been displayed long enough.
+type bannerExpiredMsg struct {
+	id int
+}
 
 // Model representing the status.
 type Model struct {
@@ -39,12 +52,19 @@ type Model struct {
 	requestor *messages.Requestor
 
 	// fast catchup state
-	progress          progress.Model
-	processedAcctsPct float64
-	verifiedAcctsPct  float64
-	acquiredBlksPct   float64
+	progress    progress.Model
+	catchup     catchupProgress
+	lastCatchup *catchupProgress
+
+	// banner is the result of the last fast catchup request.
+	banner    string
+	bannerErr bool
+	bannerID  int
 
-	// round time calculation state
+	// rounds are the latest blocks, used for the round time.
+	rounds rounds.Window
+
+	// round time calculation state before blocks are available
 	startBlock  uint64
 	startTime   time.Time
 	latestBlock uint64
@@ -55,8 +75,9 @@ type Model struct {
 func New(style *style.Styles, requestor *messages.Requestor) Model {
 	return Model{
 		style:     style,
-		progress:  progress.New(progress.WithDefaultGradient()),
+		progress:  progress.New(progress.WithDefaultGradient(), progress.WithWidth(progressWidth)),
 		requestor: requestor,
+		rounds:    rounds.NewWindow(),
 	}
 }
 
@@ -68,7 +89,12 @@ func (m Model) Init() tea.Cmd {
 	)
 }
 
+// averageBlockTime is the mean round time of the latest blocks, or since the
+// UI started while the blocks are loading.
 func (m Model) averageBlockTime() time.Duration {
+	if d, ok := m.rounds.Mean(); ok && d > 0 {
+		return d
+	}
 	numBlocks := int64(m.latestBlock - m.startBlock)
 
 	// Default round time during first seen block
@@ -85,6 +111,9 @@ func (m Model) averageBlockTime() time.Duration {
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case explorer.BlocksMsg:
+		if msg.Err == nil {
+			m.rounds.Add(msg.Blocks)
+		}
 		// Still initializing.
 		if m.Status.LastRound == 0 {
 			return m, nil
@@ -119,14 +148,8 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		util.AppKeys.Catchup.SetEnabled(m.Status.Catchpoint == "")
 		util.AppKeys.AbortCatchup.SetEnabled(m.Status.Catchpoint != "")
 
-		if m.Status.CatchpointTotalAccounts > 0 {
-			m.processedAcctsPct = float64(m.Status.CatchpointProcessedAccounts) / float64(m.Status.CatchpointTotalAccounts)
-			m.verifiedAcctsPct = float64(m.Status.CatchpointVerifiedAccounts) / float64(m.Status.CatchpointTotalAccounts)
-		}
-		if m.Status.CatchpointTotalBlocks > 0 {
-			m.processedAcctsPct = 1
-			m.verifiedAcctsPct = 1
-			m.acquiredBlksPct = float64(m.Status.CatchpointAcquiredBlocks) / float64(m.Status.CatchpointTotalBlocks)
+		if summary := updateCatchup(&m.catchup, m.Status, time.Now()); summary != nil {
+			m.lastCatchup = summary
 		}
 
 		return m, tea.Tick(100*time.Millisecond, func(time.Time) tea.Msg {
@@ -137,6 +160,15 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.Network = msg
 		return m, nil
 
+	case messages.FastCatchupMsg:
+		return m.showBanner(msg)
+
+	case bannerExpiredMsg:
+		if msg.id == m.bannerID {
+			m.banner = ""
+		}
+		return m, nil
+
 	case progress.FrameMsg:
 		progressModel, cmd := m.progress.Update(msg)
 		m.progress = progressModel.(progress.Model)
@@ -147,6 +179,33 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	}
 }
 
+// showBanner displays the result of a fast catchup request.
+func (m Model) showBanner(msg messages.FastCatchupMsg) (Model, tea.Cmd) {
+	label := strings.Split(msg.Catchpoint, "#")[0]
+	switch {
+	case msg.Err != nil && msg.Action == messages.CatchupAbort:
+		m.banner = fmt.Sprintf("Unable to abort fast catchup: %s", msg.Err)
+	case msg.Err != nil:
+		m.banner = fmt.Sprintf("Unable to start fast catchup: %s", msg.Err)
+	case msg.Action == messages.CatchupAbort:
+		m.banner = fmt.Sprintf("Aborted fast catchup to round %s.", label)
+	default:
+		m.banner = fmt.Sprintf("Started fast catchup to round %s.", label)
+	}
+	m.banner = strings.ReplaceAll(m.banner, "\n", " ")
+	m.bannerErr = msg.Err != nil
+	m.bannerID++
+	id := m.bannerID
+	return m, tea.Tick(bannerDuration, func(time.Time) tea.Msg {
+		return bannerExpiredMsg{id: id}
+	})
+}
+
+// wrap fits text in the status box.
+func (m Model) wrap(text string) string {
+	return lipgloss.NewStyle().Width(m.style.Status.GetWidth() - m.style.Status.GetHorizontalPadding()).Render(text)
+}
+
 func formatVersion(v string) string {
 	i := strings.LastIndex(v, "/")
 	if i != 0 {
@@ -155,9 +214,15 @@ func formatVersion(v string) string {
 	return v[i:]
 }
 
-func writeProgress(b *strings.Builder, prefix string, progress progress.Model, pct float64) {
+func writeProgress(b *strings.Builder, prefix string, progress progress.Model, p phaseProgress) {
 	b.WriteString(prefix)
-	b.WriteString(progress.ViewAs(pct))
+	b.WriteString(progress.ViewAs(p.fraction()))
+	if p.started() {
+		b.WriteString(" ")
+		b.WriteString(util.Sparkline(p.rates, rateSparkWidth))
+		b.WriteString(" ")
+		b.WriteString(phaseInfo(p))
+	}
 	b.WriteString("\n")
 }
 
@@ -196,35 +261,37 @@ func (m Model) View() string {
 			}
 			builder.WriteString(bold.Render(catchupStatus))
 			builder.WriteString("\n")
-			writeProgress(&builder, "Downloading accounts: ", m.progress, m.processedAcctsPct)
-			writeProgress(&builder, "Processing accounts:  ", m.progress, m.verifiedAcctsPct)
-			writeProgress(&builder, "Downloading blocks:   ", m.progress, m.acquiredBlksPct)
+			for i, p := range m.catchup.phases {
+				writeProgress(&builder, fmt.Sprintf("%-22s", phaseNames[i]+":"), m.progress, p)
+			}
 			height -= 7
 		default:
 			builder.WriteString(fmt.Sprintf("Current round:   %s\n", key.Render(strconv.FormatUint(m.Status.LastRound, 10))))
 			builder.WriteString(fmt.Sprintf("Block wait time: %s\n", time.Duration(m.Status.TimeSinceLastRound).Round(roundTo)))
 			builder.WriteString(fmt.Sprintf("Sync time:       %s\n", time.Duration(m.Status.CatchupTime).Round(roundTo)))
 			height -= 3
-			if m.Header.UpgradeState != (types.UpgradeState{}) && (uint64(m.Header.UpgradeState.NextProtocolVoteBefore) > m.Status.LastRound) {
-				//remainingToUpgrade := m.calculateTimeToGo(
-				//	m.Status.LastRound, uint64(m.Header.NextProtocolSwitchOn), m.style.AccountBlueText)
-				remainingToVote := m.calculateTimeToGo(
-					m.Status.LastRound, uint64(m.Header.NextProtocolVoteBefore), m.style.AccountBlueText)
-
-				// calculate yes/no votes
-				votesToGo := uint64(m.Header.NextProtocolVoteBefore) - m.Status.LastRound
-				votes := upgradeVoteRounds - votesToGo
-				voteYes := m.Header.NextProtocolApprovals
-				voteNo := votes - voteYes
-				voteString := fmt.Sprintf("%d / %d", voteYes, voteNo)
-				yesPct := float64(voteYes) / float64(votes)
-				windowPct := float64(votes) / float64(upgradeVoteRounds)
+			if m.lastCatchup != nil {
+				summary := m.wrap(m.lastCatchup.summary())
+				builder.WriteString(summary)
+				builder.WriteString("\n")
+				height -= lipgloss.Height(summary)
+			}
+			if vote, ok := upgrade.NewVote(m.Header); ok && vote.Open() && vote.End > m.Status.LastRound {
+				remainingToVote := m.calculateTimeToGo(m.Status.LastRound, vote.End, m.style.AccountBlueText)
+
+				// the vote parameters come from the consensus table.
+				voteString := fmt.Sprintf("%d / %d", vote.Yes, vote.No)
+				yesPct := 0.0
+				if vote.Voted() > 0 {
+					yesPct = float64(vote.Yes) / float64(vote.Voted())
+				}
+				windowPct := float64(vote.Voted()) / float64(vote.Rounds())
+				requiredPct := float64(vote.Threshold) / float64(vote.Rounds())
 				builder.WriteString(fmt.Sprintf("%s\n", bold.Render("Consensus Upgrade Pending: Votes")))
-				builder.WriteString(fmt.Sprintf("Next Protocol:     %s\n", formatVersion(m.Header.NextProtocol)))
-				builder.WriteString(fmt.Sprintf("Yes/No votes:      %s (%.0f%%, 90%% required)\n", voteString, yesPct*100))
-				//builder.WriteString(fmt.Sprintf("Vote window:      %s (%f%%)\n", voteString, *100))
+				builder.WriteString(fmt.Sprintf("Next Protocol:     %s\n", formatVersion(vote.Protocol)))
+				builder.WriteString(fmt.Sprintf("Yes/No votes:      %s (%.0f%%, %.0f%% required)\n", voteString, yesPct*100, requiredPct*100))
 				builder.WriteString(fmt.Sprintf("Vote window close: %d (%.0f%%, %s)\n",
-					m.Header.UpgradeState.NextProtocolVoteBefore,
+					vote.End,
 					windowPct*100,
 					remainingToVote))
 
@@ -251,6 +318,17 @@ func (m Model) View() string {
 		}
 	}
 
+	if m.banner != "" {
+		style := successStyle
+		if m.bannerErr {
+			style = errorStyle
+		}
+		banner := m.wrap(style.Render(m.banner))
+		builder.WriteString(banner)
+		builder.WriteString("\n")
+		height -= lipgloss.Height(banner)
+	}
+
 	// pad the box
 	for height > 0 {
 		builder.WriteString("\n")
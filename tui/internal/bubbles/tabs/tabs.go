@@ -103,29 +103,61 @@ func max(a, b int) int {
 	return b
 }
 
+// visibleRange picks the tabs around the active tab which fit in the width.
+func (m Model) visibleRange(rendered []string, available int) (int, int) {
+	first, last := m.index, m.index+1
+	width := lipgloss.Width(rendered[m.index])
+	for grew := true; grew; {
+		grew = false
+		if last < len(rendered) && width+lipgloss.Width(rendered[last]) <= available {
+			width += lipgloss.Width(rendered[last])
+			last++
+			grew = true
+		}
+		if first > 0 && width+lipgloss.Width(rendered[first-1]) <= available {
+			first--
+			width += lipgloss.Width(rendered[first])
+			grew = true
+		}
+	}
+	return first, last
+}
+
 // View is part of the tea.Model interface.
 func (m Model) View() string {
 	doc := strings.Builder{}
 
 	// Tabs
 	{
-		var renderedTabs []string
-		renderedTabs = append(renderedTabs, "\n"+tabGap.Render(strings.Repeat(" ", 5)))
+		leftGap := "\n" + tabGap.Render(strings.Repeat(" ", 5))
 
 		// Activate the correct tab
+		var tabs []string
 		for i, t := range m.tabs {
 			if i == m.index {
-				renderedTabs = append(renderedTabs, activeTab.Render(t))
+				tabs = append(tabs, activeTab.Render(t))
 			} else {
-				renderedTabs = append(renderedTabs, tab.Render(t))
+				tabs = append(tabs, tab.Render(t))
 			}
 		}
 
+		// Hide tabs that don't fit, arrows show that there are more.
+		more := "\n" + tabGap.Render("…")
+		first, last := m.visibleRange(tabs, m.width-lipgloss.Width(leftGap)-2*lipgloss.Width(more))
+		renderedTabs := []string{leftGap}
+		if first > 0 {
+			renderedTabs = append(renderedTabs, more)
+		}
+		renderedTabs = append(renderedTabs, tabs[first:last]...)
+		if last < len(tabs) {
+			renderedTabs = append(renderedTabs, more)
+		}
+
 		row := lipgloss.JoinHorizontal(
 			lipgloss.Top,
 			renderedTabs...,
 		)
-		gap := tabGap.Render(strings.Repeat(" ", max(0, m.width-lipgloss.Width(row))))
+		gap := tabGap.Render(strings.Repeat(" ", max(0, m.width-lipgloss.Width(row)-tabGap.GetHorizontalPadding())))
 
 		row = lipgloss.JoinHorizontal(lipgloss.Bottom, row, gap)
 		doc.WriteString(row)
@@ -0,0 +1,109 @@
+package upgrade
+
+import (
+	"github.com/algorand/go-algorand-sdk/v2/protocol"
+	"github.com/algorand/go-algorand-sdk/v2/protocol/config"
+	"github.com/algorand/go-algorand-sdk/v2/types"
+)
+
+// ConsensusParams returns the parameters of a protocol from the consensus table
+// bundled with the SDK. Protocols newer than the table use the parameters of
+// the latest version it has, known is false.
+func ConsensusParams(proto string) (params config.ConsensusParams, known bool) {
+	params, known = config.Consensus[protocol.ConsensusVersion(proto)]
+	if !known {
+		params = config.Consensus[protocol.ConsensusCurrentVersion]
+	}
+	return params, known
+}
+
+// Vote is the progress of a protocol upgrade vote as of a block.
+type Vote struct {
+	Protocol string
+	// Start is the round the upgrade was proposed, votes are counted from
+	// Start until End, excluded.
+	Start    uint64
+	End      uint64
+	SwitchOn uint64
+
+	// Round is the block the vote was read from.
+	Round uint64
+	Yes   uint64
+	No    uint64
+
+	Threshold uint64
+	// Known is false when the protocol isn't in the consensus table.
+	Known bool
+}
+
+// NewVote reads the upgrade vote from a block header, ok is false when there is
+// no upgrade proposal.
+func NewVote(header types.BlockHeader) (vote Vote, ok bool) {
+	state := header.UpgradeState
+	if state.NextProtocol == "" || state.NextProtocolVoteBefore == 0 {
+		return Vote{}, false
+	}
+	params, known := ConsensusParams(state.CurrentProtocol)
+	vote = Vote{
+		Protocol:  state.NextProtocol,
+		End:       uint64(state.NextProtocolVoteBefore),
+		SwitchOn:  uint64(state.NextProtocolSwitchOn),
+		Round:     uint64(header.Round),
+		Yes:       state.NextProtocolApprovals,
+		Threshold: params.UpgradeThreshold,
+		Known:     known,
+	}
+	if vote.End > params.UpgradeVoteRounds {
+		vote.Start = vote.End - params.UpgradeVoteRounds
+	}
+	if voted := vote.Voted(); voted > vote.Yes {
+		vote.No = voted - vote.Yes
+	}
+	return vote, true
+}
+
+// Rounds is the length of the voting window.
+func (v Vote) Rounds() uint64 {
+	return v.End - v.Start
+}
+
+// Voted is the number of blocks which voted so far.
+func (v Vote) Voted() uint64 {
+	switch {
+	case v.Round < v.Start:
+		return 0
+	case v.Round >= v.End:
+		return v.Rounds()
+	}
+	return v.Round - v.Start + 1
+}
+
+// Remaining is the number of blocks which may still vote.
+func (v Vote) Remaining() uint64 {
+	return v.Rounds() - v.Voted()
+}
+
+// Open is true while blocks may still vote.
+func (v Vote) Open() bool {
+	return v.Remaining() > 0
+}
+
+// Approved is true once the yes votes reach the threshold.
+func (v Vote) Approved() bool {
+	return v.Yes >= v.Threshold
+}
+
+// Failed is true when the threshold can't be reached anymore.
+func (v Vote) Failed() bool {
+	return v.Yes+v.Remaining() < v.Threshold
+}
+
+// Projected is the number of yes votes at the end of the window if the
+// remaining blocks vote like the previous ones.
+func (v Vote) Projected() uint64 {
+	voted := v.Voted()
+	if voted == 0 {
+		return v.Yes
+	}
+	return v.Yes + v.Remaining()*v.Yes/voted
+}
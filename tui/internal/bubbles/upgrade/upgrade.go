@@ -0,0 +1,386 @@
+// Package upgrade displays the consensus protocol upgrade votes.
+package upgrade
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/algorand/go-algorand-sdk/v2/client/v2/common/models"
+	"github.com/algorand/go-algorand-sdk/v2/protocol"
+	"github.com/algorand/go-algorand-sdk/v2/types"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/winder/algorand-navigator/messages"
+	"github.com/winder/algorand-navigator/tui/internal/bubbles/accounts"
+	"github.com/winder/algorand-navigator/tui/internal/bubbles/explorer"
+	"github.com/winder/algorand-navigator/tui/internal/bubbles/rounds"
+	"github.com/winder/algorand-navigator/tui/internal/style"
+)
+
+const (
+	// chartHeight is the number of rows of the vote chart.
+	chartHeight = 8
+	// chartFloor is the share of yes votes at the bottom of the chart, lower
+	// shares are all no.
+	chartFloor = 50.0
+
+	// maxProposers is the number of proposers listed.
+	maxProposers = 10
+
+	// columnGap separates the votes from the proposers.
+	columnGap = 4
+)
+
+var (
+	yesStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("#2AB56F"))
+	noStyle      = lipgloss.NewStyle().Foreground(lipgloss.Color("#E35D5D"))
+	dimStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("#6C6C6C"))
+	warningStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#E3A322")).Bold(true)
+	watchedStyle = yesStyle.Copy().Bold(true)
+)
+
+// samplesMsg has the blocks at the boundaries of the chart columns.
+type samplesMsg struct {
+	blocks []explorer.BlockItem
+	err    error
+}
+
+// Model representing the upgrade page.
+type Model struct {
+	style        *style.Styles
+	width        int
+	height       int
+	heightMargin int
+
+	status models.NodeStatus
+	tally  tally
+	// rounds are used to project the time of the upgrade.
+	rounds rounds.Window
+
+	// fetching is set while the samples are downloaded, err is the last
+	// failure which stops further attempts for this proposal.
+	fetching bool
+	err      error
+
+	watched []types.Address
+	labels  map[types.Address]string
+
+	requestor *messages.Requestor
+}
+
+// New creates the upgrade Model.
+func New(styles *style.Styles, requestor *messages.Requestor, heightMargin int) Model {
+	return Model{
+		style:        styles,
+		heightMargin: heightMargin,
+		tally:        newTally(),
+		rounds:       rounds.NewWindow(),
+		labels:       make(map[types.Address]string),
+		requestor:    requestor,
+	}
+}
+
+// Init is part of the tea.Model interface.
+func (m Model) Init() tea.Cmd {
+	return nil
+}
+
+// Update is part of the tea.Model interface.
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+
+	case messages.StatusMsg:
+		if msg.Error == nil {
+			m.status = msg.Status
+		}
+
+	case accounts.WatchListMsg:
+		m.watched = msg.Addresses
+		m.labels = msg.Labels
+
+	case explorer.BlocksMsg:
+		if msg.Err != nil {
+			break
+		}
+		m.rounds.Add(msg.Blocks)
+		if m.tally.add(msg.Blocks) {
+			m.err = nil
+		}
+		return m.fetchSamples()
+
+	case samplesMsg:
+		m.fetching = false
+		m.err = msg.err
+		m.tally.add(msg.blocks)
+	}
+	return m, nil
+}
+
+// fetchSamples downloads the blocks which are missing from the chart.
+func (m Model) fetchSamples() (Model, tea.Cmd) {
+	missing := m.tally.missing()
+	if m.fetching || m.err != nil || len(missing) == 0 {
+		return m, nil
+	}
+	m.fetching = true
+	requestor := m.requestor
+	return m, func() tea.Msg {
+		var blocks []explorer.BlockItem
+		for _, round := range missing {
+			blk, err := explorer.GetBlock(requestor, round)
+			if err != nil {
+				return samplesMsg{blocks: blocks, err: err}
+			}
+			blocks = append(blocks, blk)
+		}
+		return samplesMsg{blocks: blocks}
+	}
+}
+
+// View is part of the tea.Model interface.
+func (m Model) View() string {
+	var content string
+	switch {
+	case m.tally.latest == 0:
+		content = "Waiting for blocks..."
+	case !m.tally.active:
+		content = m.protocolView()
+	default:
+		content = lipgloss.JoinHorizontal(lipgloss.Top,
+			lipgloss.NewStyle().PaddingRight(columnGap).Render(m.voteView()),
+			m.proposersView())
+	}
+
+	height := m.height - m.heightMargin - m.style.Bottom.GetVerticalBorderSize()
+	return m.style.Bottom.Copy().Height(max(0, height)).Render(content)
+}
+
+// shortVersion is the last part of a protocol version URL.
+func shortVersion(v string) string {
+	return v[strings.LastIndex(v, "/")+1:]
+}
+
+// projectedTime estimates when a future round is reached, it is empty while
+// the round time isn't known.
+func (m Model) projectedTime(round uint64) string {
+	mean, ok := m.rounds.Mean()
+	if !ok || round <= m.tally.latest {
+		return ""
+	}
+	remaining := time.Duration(round-m.tally.latest) * mean
+	return fmt.Sprintf(", in %s around %s",
+		remaining.Round(time.Minute), time.Now().Add(remaining).Format("Mon Jan 2 15:04"))
+}
+
+// protocolView describes the upgrade parameters of the current protocol.
+func (m Model) protocolView() string {
+	bold := m.style.StatusBoldText
+	current := m.status.LastVersion
+	params, known := ConsensusParams(current)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %s\n", bold.Render("Protocol:"), shortVersion(current))
+	b.WriteString("No upgrade in progress.\n\n")
+	if !known {
+		b.WriteString(warningStyle.Render(fmt.Sprintf("The protocol isn't in the bundled consensus table, the parameters of %s are shown.",
+			shortVersion(string(protocol.ConsensusCurrentVersion)))))
+		b.WriteString("\n")
+	}
+	b.WriteString(bold.Render("Upgrade parameters:"))
+	b.WriteString("\n")
+	fmt.Fprintf(&b, "Voting window:  %d rounds\n", params.UpgradeVoteRounds)
+	fmt.Fprintf(&b, "Threshold:      %d yes votes (%.0f%%)\n",
+		params.UpgradeThreshold, percent(params.UpgradeThreshold, params.UpgradeVoteRounds))
+	fmt.Fprintf(&b, "Upgrade delay:  %d rounds after the vote", params.DefaultUpgradeWaitRounds)
+	if params.MaxUpgradeWaitRounds > 0 {
+		fmt.Fprintf(&b, ", proposers may pick %d to %d", params.MinUpgradeWaitRounds, params.MaxUpgradeWaitRounds)
+	}
+	b.WriteString("\n")
+	return b.String()
+}
+
+func percent(part, total uint64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return 100 * float64(part) / float64(total)
+}
+
+// voteView describes the vote in progress with its chart.
+func (m Model) voteView() string {
+	bold := m.style.StatusBoldText
+	vote := m.tally.vote
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %s\n", bold.Render("Upgrade to:"), shortVersion(vote.Protocol))
+	fmt.Fprintf(&b, "Proposed in round %d, votes until round %d.\n", vote.Start, vote.End-1)
+	if !vote.Known {
+		b.WriteString(warningStyle.Render("The protocol isn't in the bundled consensus table, the vote parameters may be wrong."))
+		b.WriteString("\n")
+	}
+	fmt.Fprintf(&b, "Yes/No votes: %s / %s (%.1f%% yes, %d required)\n",
+		yesStyle.Render(fmt.Sprint(vote.Yes)), noStyle.Render(fmt.Sprint(vote.No)),
+		percent(vote.Yes, vote.Voted()), vote.Threshold)
+
+	switch {
+	case vote.Approved():
+		b.WriteString(yesStyle.Render("The upgrade is approved."))
+	case vote.Failed():
+		b.WriteString(noStyle.Render("The upgrade can't be approved anymore."))
+	default:
+		projected := vote.Projected()
+		result := noStyle.Render("rejected")
+		if projected >= vote.Threshold {
+			result = yesStyle.Render("approved")
+		}
+		fmt.Fprintf(&b, "%d more yes votes needed in %d rounds, projected %d: %s.",
+			vote.Threshold-vote.Yes, vote.Remaining(), projected, result)
+	}
+	b.WriteString("\n")
+	if vote.Open() {
+		fmt.Fprintf(&b, "Vote closes:  round %d%s\n", vote.End, m.projectedTime(vote.End))
+	}
+	fmt.Fprintf(&b, "Switch round: %d%s\n", vote.SwitchOn, m.projectedTime(vote.SwitchOn))
+	if m.status.NextVersion == vote.Protocol && !m.status.NextVersionSupported {
+		b.WriteString(warningStyle.Render("This node doesn't support the new protocol, it stops at the switch round unless it is updated."))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(m.chartView())
+	if m.fetching {
+		b.WriteString("Loading the vote history...\n")
+	}
+	if m.err != nil {
+		b.WriteString(warningStyle.Render(fmt.Sprintf("Unable to load the vote history: %s", m.err)))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// chartView plots the share of yes votes of each part of the voting window.
+func (m Model) chartView() string {
+	columns := m.tally.columns()
+	threshold := percent(m.tally.vote.Threshold, m.tally.vote.Rounds())
+	thresholdRow := int(math.Ceil((threshold - chartFloor) / (100 - chartFloor) * chartHeight))
+	var b strings.Builder
+	for row := chartHeight; row > 0; row-- {
+		switch row {
+		case chartHeight:
+			b.WriteString("100% ")
+		case thresholdRow:
+			fmt.Fprintf(&b, "%3.0f%% ", threshold)
+		case 1:
+			fmt.Fprintf(&b, "%3.0f%% ", chartFloor)
+		default:
+			b.WriteString("     ")
+		}
+		// the row is yes when the share reaches its middle.
+		middle := chartFloor + (100-chartFloor)*(float64(row)-0.5)/chartHeight
+		for _, c := range columns {
+			switch {
+			case c.future:
+				b.WriteString(" ")
+			case !c.known:
+				if row == 1 {
+					b.WriteString(dimStyle.Render("?"))
+				} else {
+					b.WriteString(" ")
+				}
+			case percent(c.yes, c.rounds) >= middle:
+				b.WriteString(yesStyle.Render("█"))
+			default:
+				b.WriteString(noStyle.Render("█"))
+			}
+		}
+		b.WriteString("\n")
+	}
+
+	first := fmt.Sprint(m.tally.vote.Start)
+	last := fmt.Sprint(m.tally.vote.End - 1)
+	fmt.Fprintf(&b, "     %s%*s\n", first, len(columns)-len(first), last)
+	fmt.Fprintf(&b, "%s yes %s no, %d rounds per column, %.0f%% required.\n",
+		yesStyle.Render("█"), noStyle.Render("█"), m.tally.vote.Rounds()/chartColumns, threshold)
+	return b.String()
+}
+
+// name is the label of an address, or the short address.
+func (m Model) name(addr types.Address) string {
+	s := addr.String()
+	short := s[:6] + "…" + s[len(s)-4:]
+	if label := m.labels[addr]; label != "" {
+		return fmt.Sprintf("%s (%s)", label, short)
+	}
+	return short
+}
+
+// proposersView lists the recent proposers which voted yes.
+func (m Model) proposersView() string {
+	bold := m.style.StatusBoldText
+	votes, blocks := m.tally.proposers()
+	watched := make(map[types.Address]bool, len(m.watched))
+	for _, addr := range m.watched {
+		watched[addr] = true
+	}
+
+	var b strings.Builder
+	b.WriteString(bold.Render("Recent proposers who voted yes:"))
+	fmt.Fprintf(&b, " %d blocks\n", blocks)
+	if blocks == 0 {
+		b.WriteString("The block certificates are not available.\n")
+	}
+	var yes, no []proposerVotes
+	for _, v := range votes {
+		if v.yes > 0 {
+			yes = append(yes, v)
+		} else {
+			no = append(no, v)
+		}
+	}
+	for i, v := range yes {
+		if i == maxProposers {
+			fmt.Fprintf(&b, "and %d more\n", len(yes)-maxProposers)
+			break
+		}
+		row := fmt.Sprintf("%-24s %4d yes", m.name(v.address), v.yes)
+		if v.no > 0 {
+			row += fmt.Sprintf(" %4d no", v.no)
+		}
+		if watched[v.address] {
+			row = watchedStyle.Render(row)
+		}
+		b.WriteString(row)
+		b.WriteString("\n")
+	}
+
+	if len(no) > 0 {
+		b.WriteString("\n")
+		fmt.Fprintf(&b, "%s %d accounts\n", bold.Render("Proposed without voting yes:"), len(no))
+		for i, v := range no {
+			if i == maxProposers/2 {
+				fmt.Fprintf(&b, "and %d more\n", len(no)-maxProposers/2)
+				break
+			}
+			row := fmt.Sprintf("%-24s %4d blocks", m.name(v.address), v.no)
+			if watched[v.address] {
+				row = warningStyle.Render(row)
+			}
+			b.WriteString(row)
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
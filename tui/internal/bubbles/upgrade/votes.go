@@ -0,0 +1,204 @@
+package upgrade
+
+import (
+	"sort"
+
+	"github.com/algorand/go-algorand-sdk/v2/types"
+
+	"github.com/winder/algorand-navigator/tui/internal/bubbles/explorer"
+)
+
+const (
+	// chartColumns is the number of columns of the vote chart, the voting
+	// window is sampled at the end of each column.
+	chartColumns = 50
+
+	// recentRounds is the number of latest blocks whose proposers are listed.
+	recentRounds = 1000
+)
+
+// ballot is the upgrade vote of a block.
+type ballot struct {
+	proposer    types.Address
+	hasProposer bool
+	approve     bool
+}
+
+// tally reconstructs the votes of an upgrade proposal from block headers.
+type tally struct {
+	// active is set while the latest block has an upgrade proposal.
+	active bool
+	vote   Vote
+	latest uint64
+
+	// approvals are the yes votes so far, by round.
+	approvals map[uint64]uint64
+	// ballots are the votes of the latest blocks.
+	ballots map[uint64]ballot
+}
+
+func newTally() tally {
+	return tally{
+		approvals: make(map[uint64]uint64),
+		ballots:   make(map[uint64]ballot),
+	}
+}
+
+// sameProposal is true when both votes are for the same upgrade proposal.
+func sameProposal(a, b Vote) bool {
+	return a.Protocol == b.Protocol && a.End == b.End
+}
+
+// add records the votes of the blocks, it returns true when the proposal
+// changed.
+func (t *tally) add(blocks []explorer.BlockItem) (changed bool) {
+	// the newest block decides which proposal is counted.
+	for _, blk := range blocks {
+		if blk.Round <= t.latest {
+			continue
+		}
+		t.latest = blk.Round
+		vote, ok := NewVote(blk.Block.Block.BlockHeader)
+		if ok != t.active || (ok && !sameProposal(vote, t.vote)) {
+			latest := t.latest
+			*t = newTally()
+			t.latest = latest
+			t.active = ok
+			changed = true
+		}
+		if ok {
+			t.vote = vote
+		}
+	}
+
+	for _, blk := range blocks {
+		header := blk.Block.Block.BlockHeader
+		vote, ok := NewVote(header)
+		if !ok || !t.active || !sameProposal(vote, t.vote) {
+			continue
+		}
+
+		t.approvals[blk.Round] = vote.Yes
+		if blk.Round < vote.End && blk.Round+recentRounds > t.latest {
+			b := ballot{approve: header.UpgradeApprove}
+			b.proposer, b.hasProposer = blk.Proposer()
+			t.ballots[blk.Round] = b
+		}
+	}
+	for round := range t.ballots {
+		if round+recentRounds <= t.latest {
+			delete(t.ballots, round)
+		}
+	}
+	return changed
+}
+
+// boundaries are the last round of each chart column.
+func (t tally) boundaries() []uint64 {
+	rounds := t.vote.Rounds()
+	boundaries := make([]uint64, chartColumns)
+	for k := range boundaries {
+		// rounded up so that the last column ends with the window.
+		end := (uint64(k+1)*rounds + chartColumns - 1) / chartColumns
+		boundaries[k] = t.vote.Start + end - 1
+	}
+	return boundaries
+}
+
+// missing returns the boundaries which were reached but aren't known.
+func (t tally) missing() []uint64 {
+	var rounds []uint64
+	if !t.active {
+		return nil
+	}
+	for _, round := range t.boundaries() {
+		if _, ok := t.approvals[round]; !ok && round <= t.latest {
+			rounds = append(rounds, round)
+		}
+	}
+	return rounds
+}
+
+// column is the votes of a part of the voting window.
+type column struct {
+	rounds uint64
+	yes    uint64
+	// known is false while the votes at the boundaries are not available.
+	known bool
+	// future columns haven't started.
+	future bool
+}
+
+// columns splits the voting window for the chart, the column of the latest
+// block is partial.
+func (t tally) columns() []column {
+	columns := make([]column, 0, chartColumns)
+	// first is the first round of the column.
+	first := t.vote.Start
+	for _, boundary := range t.boundaries() {
+		c := column{future: first > t.latest}
+		end := boundary
+		if end > t.latest {
+			end = t.latest
+		}
+		if !c.future && end >= first {
+			before, ok := uint64(0), true
+			if first > t.vote.Start {
+				before, ok = t.approvals[first-1]
+			}
+			after, found := t.approvals[end]
+			if ok && found && after >= before {
+				c.known = true
+				c.rounds = end - first + 1
+				c.yes = after - before
+			}
+		}
+		columns = append(columns, c)
+		first = boundary + 1
+	}
+	return columns
+}
+
+// proposerVotes is the number of latest blocks an account proposed.
+type proposerVotes struct {
+	address types.Address
+	yes     int
+	no      int
+}
+
+// proposers counts the votes of the latest blocks by proposer, most yes votes
+// first. blocks is the number of blocks with a known proposer.
+func (t tally) proposers() (votes []proposerVotes, blocks int) {
+	byAddress := make(map[types.Address]*proposerVotes)
+	for _, b := range t.ballots {
+		if !b.hasProposer {
+			continue
+		}
+		blocks++
+		v, ok := byAddress[b.proposer]
+		if !ok {
+			v = &proposerVotes{address: b.proposer}
+			byAddress[b.proposer] = v
+		}
+		if b.approve {
+			v.yes++
+		} else {
+			v.no++
+		}
+	}
+
+	votes = make([]proposerVotes, 0, len(byAddress))
+	for _, v := range byAddress {
+		votes = append(votes, *v)
+	}
+	sort.Slice(votes, func(i, j int) bool {
+		if votes[i].yes != votes[j].yes {
+			return votes[i].yes > votes[j].yes
+		}
+		if votes[i].no != votes[j].no {
+			return votes[i].no > votes[j].no
+		}
+		return votes[i].address.String() < votes[j].address.String()
+	})
+	return votes, blocks
+}
@@ -0,0 +1,168 @@
+package utilities
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/winder/algorand-navigator/messages"
+	"github.com/winder/algorand-navigator/tui/internal/util"
+)
+
+// catchpoint sources offered by the picker.
+const (
+	latestSource = iota
+	labelSource
+	fileSource
+)
+
+// catchupChoices are the options of the catchup source step.
+func (m Model) catchupChoices() []string {
+	return []string{
+		fmt.Sprintf("Latest catchpoint from %s", m.requestor.CatchpointSource()),
+		"Paste a catchpoint label",
+		"Read the catchpoint label from a file",
+	}
+}
+
+// startCatchup opens the catchpoint picker.
+func (m Model) startCatchup() (Model, tea.Cmd) {
+	m.catchpoint = messages.Catchpoint{}
+	m.catchpointSource = ""
+	return m.setStep(catchupSourceStep)
+}
+
+func (m Model) updateCatchupSource(msg tea.KeyMsg) (Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, util.AppKeys.Back):
+		return m.setStep(menuStep)
+	case msg.String() == "up" || msg.String() == "k":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case msg.String() == "down" || msg.String() == "j":
+		if m.cursor < len(m.catchupChoices())-1 {
+			m.cursor++
+		}
+	case key.Matches(msg, util.AppKeys.Forward):
+		switch m.cursor {
+		case latestSource:
+			m, _ = m.setStep(catchupFetchStep)
+			return m, m.requestor.GetLatestCatchpointCmd(m.genesisID)
+		case labelSource:
+			return m.setStep(catchupLabelStep)
+		case fileSource:
+			return m.setStep(catchupFileStep)
+		}
+	}
+	return m, nil
+}
+
+func (m Model) updateCatchupPrompt(msg tea.KeyMsg) (Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, util.AppKeys.Back):
+		m.prompt.Reset()
+		return m.setStep(catchupSourceStep)
+	case key.Matches(msg, util.AppKeys.Forward):
+		value := strings.TrimSpace(m.prompt.Value())
+		if value == "" {
+			return m, nil
+		}
+		switch m.step {
+		case catchupLabelStep:
+			catchpoint, err := messages.ParseCatchpoint(value)
+			if err != nil {
+				m.err = err
+				return m, nil
+			}
+			return m.setCatchpoint(messages.CatchpointMsg{Catchpoint: catchpoint, Source: "pasted label"})
+		case catchupFileStep:
+			m, _ = m.setStep(catchupFetchStep)
+			return m, messages.ReadCatchpointFileCmd(value)
+		}
+	}
+
+	var cmd tea.Cmd
+	m.prompt, cmd = m.prompt.Update(msg)
+	return m, cmd
+}
+
+// setCatchpoint moves to the confirmation once a catchpoint was picked.
+func (m Model) setCatchpoint(msg messages.CatchpointMsg) (Model, tea.Cmd) {
+	if msg.Err != nil {
+		m, cmd := m.setStep(catchupSourceStep)
+		m.err = fmt.Errorf("unable to get a catchpoint: %w", msg.Err)
+		return m, cmd
+	}
+	m, cmd := m.setStep(catchupConfirmStep)
+	m.catchpoint = msg.Catchpoint
+	m.catchpointSource = msg.Source
+	m.err = m.catchpoint.CheckNetwork(m.genesisID)
+	return m, cmd
+}
+
+func (m Model) updateCatchupConfirm(msg tea.KeyMsg) (Model, tea.Cmd) {
+	if (msg.String() == "y" || msg.String() == "Y") && m.err == nil {
+		// the result is displayed by the status bubble.
+		m, _ = m.setStep(menuStep)
+		return m, m.requestor.StartFastCatchupCmd(m.catchpoint, m.genesisID)
+	}
+	return m.setStep(catchupSourceStep)
+}
+
+// catchupView renders the steps of the fast catchup flow.
+func (m Model) catchupView() string {
+	bold := m.style.StatusBoldText
+	var b strings.Builder
+	b.WriteString(bold.Render("Fast catchup"))
+	b.WriteString("\n\n")
+	if m.genesisID != "" {
+		fmt.Fprintf(&b, "The node is on %s.\n\n", m.genesisID)
+	}
+
+	switch m.step {
+	case catchupSourceStep:
+		b.WriteString("Select the catchpoint to catch up to:\n")
+		for i, c := range m.catchupChoices() {
+			if i == m.cursor {
+				b.WriteString(activeStyle.Render("> " + c))
+			} else {
+				b.WriteString("  " + c)
+			}
+			b.WriteString("\n")
+		}
+		b.WriteString("\nenter: select, esc: back\n")
+	case catchupLabelStep, catchupFileStep:
+		b.WriteString(m.prompt.View())
+		b.WriteString("\n\nLabels look like 30000000#HASH, they may be preceded by the genesis ID to check the network.\n")
+		b.WriteString("enter: continue, esc: back\n")
+	case catchupFetchStep:
+		b.WriteString("Fetching the catchpoint...\n\nesc: cancel\n")
+	case catchupConfirmStep:
+		fmt.Fprintf(&b, "Catchpoint: %s\n", m.catchpoint.Label)
+		fmt.Fprintf(&b, "Round:      %d\n", m.catchpoint.Round)
+		fmt.Fprintf(&b, "Source:     %s\n", m.catchpointSource)
+		switch {
+		case m.err != nil:
+		case m.catchpoint.Network == "":
+			b.WriteString(warningStyle.Render("Warning: the network of this catchpoint is unknown, make sure it is for " + m.genesisID))
+			b.WriteString("\n")
+		default:
+			fmt.Fprintf(&b, "Network:    %s\n", m.catchpoint.Network)
+		}
+		b.WriteString("\n")
+		if m.err == nil {
+			b.WriteString("The node discards its ledger and downloads the catchpoint. Press y to start, any other key to cancel.\n")
+		} else {
+			b.WriteString("Press any key to pick another catchpoint.\n")
+		}
+	}
+
+	if m.err != nil {
+		b.WriteString(warningStyle.Render(fmt.Sprintf("Error: %s", m.err)))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
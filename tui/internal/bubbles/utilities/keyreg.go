@@ -0,0 +1,127 @@
+package utilities
+
+import (
+	"encoding/base64"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/algorand/go-algorand-sdk/v2/crypto"
+	"github.com/algorand/go-algorand-sdk/v2/encoding/msgpack"
+	"github.com/algorand/go-algorand-sdk/v2/transaction"
+	"github.com/algorand/go-algorand-sdk/v2/types"
+
+	"github.com/winder/algorand-navigator/messages"
+)
+
+// keyregRequest describes the key registration being built.
+type keyregRequest struct {
+	address string
+	online  bool
+	// key is only used for online registrations.
+	key messages.ParticipationKey
+}
+
+func (r keyregRequest) mode() string {
+	if r.online {
+		return "online"
+	}
+	return "offline"
+}
+
+func b64(b []byte) string {
+	if len(b) == 0 {
+		return ""
+	}
+	return base64.StdEncoding.EncodeToString(b)
+}
+
+// buildKeyreg creates the unsigned key registration transaction. Offline
+// registrations clear the participation keys of the account. MakeKeyRegTxn
+// rejects empty keys, so both modes use MakeKeyRegTxnWithStateProofKey.
+func buildKeyreg(req keyregRequest, params types.SuggestedParams) (types.Transaction, error) {
+	if !req.online {
+		return transaction.MakeKeyRegTxnWithStateProofKey(req.address, nil, params, "", "", "", 0, 0, 0, false)
+	}
+	k := req.key.Key
+	txn, err := transaction.MakeKeyRegTxnWithStateProofKey(req.address, nil, params,
+		b64(k.VoteParticipationKey), b64(k.SelectionParticipationKey), b64(k.StateProofKey),
+		k.VoteFirstValid, k.VoteLastValid, k.VoteKeyDilution, false)
+	if err != nil {
+		return types.Transaction{}, fmt.Errorf("participation key %s: %w", req.key.ID, err)
+	}
+	return txn, nil
+}
+
+// keyregWarnings are problems which don't prevent building the transaction.
+func keyregWarnings(req keyregRequest, txn types.Transaction) []string {
+	var warnings []string
+	if req.online && uint64(txn.VoteLast) <= uint64(txn.FirstValid) {
+		warnings = append(warnings, fmt.Sprintf("The participation key expired at round %d.", txn.VoteLast))
+	}
+	if req.online && len(req.key.Key.StateProofKey) == 0 {
+		warnings = append(warnings, "The participation key has no state proof key.")
+	}
+	return warnings
+}
+
+func writeField(b *strings.Builder, name string, value interface{}) {
+	fmt.Fprintf(b, "  %-18s %v\n", name+":", value)
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "(none)"
+	}
+	return s
+}
+
+// keyregPreview lists every field of the transaction.
+func keyregPreview(txn types.Transaction) string {
+	var b strings.Builder
+	writeField(&b, "Type", txn.Type)
+	writeField(&b, "Sender", txn.Sender)
+	writeField(&b, "Fee", fmt.Sprintf("%d µAlgos", txn.Fee))
+	writeField(&b, "First valid", txn.FirstValid)
+	writeField(&b, "Last valid", txn.LastValid)
+	writeField(&b, "Genesis ID", txn.GenesisID)
+	writeField(&b, "Genesis hash", b64(txn.GenesisHash[:]))
+	writeField(&b, "Note", orNone(string(txn.Note)))
+	if txn.VotePK != (types.VotePK{}) {
+		writeField(&b, "Vote key", b64(txn.VotePK[:]))
+		writeField(&b, "Selection key", b64(txn.SelectionPK[:]))
+	} else {
+		writeField(&b, "Vote key", "(none)")
+		writeField(&b, "Selection key", "(none)")
+	}
+	if txn.StateProofPK != (types.MerkleVerifier{}) {
+		writeField(&b, "State proof key", b64(txn.StateProofPK[:]))
+	} else {
+		writeField(&b, "State proof key", "(none)")
+	}
+	writeField(&b, "Vote first", txn.VoteFirst)
+	writeField(&b, "Vote last", txn.VoteLast)
+	writeField(&b, "Key dilution", txn.VoteKeyDilution)
+	writeField(&b, "Nonparticipation", txn.Nonparticipation)
+	writeField(&b, "Transaction ID", crypto.GetTxID(txn))
+	return b.String()
+}
+
+// defaultTxnFile is the suggested name of the unsigned transaction file.
+func defaultTxnFile(req keyregRequest) string {
+	return fmt.Sprintf("keyreg-%s-%s.txn", req.address[:8], req.mode())
+}
+
+// writeUnsigned writes the transaction in the format used by
+// 'goal clerk sign', existing files are not replaced.
+func writeUnsigned(file string, txn types.Transaction) error {
+	f, err := os.OpenFile(file, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(msgpack.Encode(types.SignedTxn{Txn: txn})); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,485 @@
+// Package utilities hosts the utility flows, such as building key
+// registration transactions.
+package utilities
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/algorand/go-algorand-sdk/v2/types"
+
+	"github.com/winder/algorand-navigator/messages"
+	"github.com/winder/algorand-navigator/tui/internal/bubbles/about"
+	"github.com/winder/algorand-navigator/tui/internal/bubbles/accounts"
+	"github.com/winder/algorand-navigator/tui/internal/style"
+	"github.com/winder/algorand-navigator/tui/internal/util"
+)
+
+var activeStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#B083EA")).Bold(true)
+var warningStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#E3A322")).Bold(true)
+
+type step int
+
+const (
+	menuStep step = iota
+	accountStep
+	modeStep
+	keyStep
+	previewStep
+	fileStep
+	walletStep
+	passwordStep
+	confirmStep
+	catchupSourceStep
+	catchupLabelStep
+	catchupFileStep
+	catchupFetchStep
+	catchupConfirmStep
+)
+
+// paramsMsg has the suggested parameters for a new transaction.
+type paramsMsg struct {
+	params types.SuggestedParams
+	err    error
+}
+
+// Model representing the utilities bubble.
+type Model struct {
+	style        *style.Styles
+	width        int
+	height       int
+	heightMargin int
+
+	// menu lists the utilities while no flow is open.
+	menu about.Model
+
+	accounts []types.Address
+	labels   map[types.Address]string
+	keys     []messages.ParticipationKey
+
+	// keyreg flow state
+	step    step
+	cursor  int
+	request keyregRequest
+	txn     types.Transaction
+	err     error
+	result  string
+	wallet  string
+	prompt  textinput.Model
+
+	// fast catchup flow state
+	genesisID        string
+	catchpoint       messages.Catchpoint
+	catchpointSource string
+
+	requestor *messages.Requestor
+}
+
+// New creates the utilities Model.
+func New(styles *style.Styles, requestor *messages.Requestor, heightMargin int, accounts []types.Address) Model {
+	return Model{
+		style:        styles,
+		heightMargin: heightMargin,
+		menu:         about.New(heightMargin, about.GetUtilsContent()),
+		accounts:     accounts,
+		prompt:       textinput.New(),
+		requestor:    requestor,
+	}
+}
+
+func (m Model) getParamsCmd() tea.Cmd {
+	return func() tea.Msg {
+		params, err := m.requestor.Client.SuggestedParams().Do(context.Background())
+		return paramsMsg{
+			params: params,
+			err:    err,
+		}
+	}
+}
+
+// Init is part of the tea.Model interface.
+func (m Model) Init() tea.Cmd {
+	return m.menu.Init()
+}
+
+// CapturingInput is part of the util.InputCapturer interface.
+func (m Model) CapturingInput() bool {
+	return m.step != menuStep
+}
+
+// UpdateKeys is part of the util.KeyUpdater interface.
+func (m Model) UpdateKeys(active bool) {
+	util.AppKeys.Keyreg.SetEnabled(active)
+}
+
+// choices are the options of the current step.
+func (m Model) choices() []string {
+	var choices []string
+	switch m.step {
+	case accountStep:
+		for _, addr := range m.accounts {
+			if label := m.labels[addr]; label != "" {
+				choices = append(choices, fmt.Sprintf("%s  %s", addr, label))
+			} else {
+				choices = append(choices, addr.String())
+			}
+		}
+	case modeStep:
+		choices = []string{
+			"Online, register a participation key",
+			"Offline, stop participating",
+		}
+	case keyStep:
+		for _, k := range m.accountKeys() {
+			choices = append(choices, fmt.Sprintf("%s  rounds %d to %d, dilution %d",
+				k.ID, k.Key.VoteFirstValid, k.Key.VoteLastValid, k.Key.VoteKeyDilution))
+		}
+	}
+	return choices
+}
+
+// accountKeys are the installed participation keys of the selected account.
+func (m Model) accountKeys() []messages.ParticipationKey {
+	var keys []messages.ParticipationKey
+	for _, k := range m.keys {
+		if k.Address == m.request.address {
+			keys = append(keys, k)
+		}
+	}
+	return keys
+}
+
+// setStep moves to a step of the flow.
+func (m Model) setStep(s step) (Model, tea.Cmd) {
+	m.step = s
+	m.cursor = 0
+	m.err = nil
+	m.prompt.Blur()
+
+	switch s {
+	case fileStep:
+		return m.openPrompt("Unsigned transaction file: ", defaultTxnFile(m.request), false)
+	case walletStep:
+		return m.openPrompt("Wallet name: ", m.wallet, false)
+	case passwordStep:
+		return m.openPrompt("Wallet password: ", "", true)
+	case catchupLabelStep:
+		return m.openPrompt("Catchpoint label: ", "", false)
+	case catchupFileStep:
+		return m.openPrompt("Catchpoint file: ", "", false)
+	}
+	return m, nil
+}
+
+func (m Model) openPrompt(prompt, value string, password bool) (Model, tea.Cmd) {
+	m.prompt.Reset()
+	m.prompt.Prompt = prompt
+	m.prompt.SetValue(value)
+	m.prompt.EchoMode = textinput.EchoNormal
+	if password {
+		m.prompt.EchoMode = textinput.EchoPassword
+	}
+	return m, m.prompt.Focus()
+}
+
+// Update is part of the tea.Model interface.
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+
+	case messages.ParticipationKeysMsg:
+		if msg.Err == nil {
+			m.keys = msg.Keys
+		}
+		return m, nil
+
+	case accounts.WatchListMsg:
+		m.accounts = msg.Addresses
+		m.labels = msg.Labels
+		if m.step == accountStep && m.cursor >= len(m.accounts) {
+			m.cursor = max(0, len(m.accounts)-1)
+		}
+		return m, nil
+
+	case paramsMsg:
+		if m.step != previewStep {
+			return m, nil
+		}
+		if msg.err != nil {
+			m.err = fmt.Errorf("unable to fetch transaction parameters: %w", msg.err)
+			return m, nil
+		}
+		m.txn, m.err = buildKeyreg(m.request, msg.params)
+		return m, nil
+
+	case messages.NetworkMsg:
+		if msg.Err == nil {
+			m.genesisID = msg.GenesisID
+		}
+		return m, nil
+
+	case messages.CatchpointMsg:
+		if m.step != catchupFetchStep {
+			return m, nil
+		}
+		return m.setCatchpoint(msg)
+
+	case messages.SignAndSendMsg:
+		if msg.Err != nil {
+			m.err = msg.Err
+			return m, nil
+		}
+		m.result = fmt.Sprintf("Sent %s key registration %s.", m.request.mode(), msg.TxID)
+		return m, nil
+
+	case tea.KeyMsg:
+		if m.step == menuStep {
+			switch {
+			case key.Matches(msg, util.AppKeys.Keyreg):
+				m.result = ""
+				return m.setStep(accountStep)
+			case key.Matches(msg, util.AppKeys.Catchup):
+				return m.startCatchup()
+			}
+			break
+		}
+		return m.updateFlow(msg)
+	}
+
+	menu, cmd := m.menu.Update(msg)
+	m.menu = menu.(about.Model)
+	return m, cmd
+}
+
+func (m Model) updateFlow(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch m.step {
+	case accountStep, modeStep, keyStep:
+		return m.updateChoice(msg)
+	case previewStep:
+		return m.updatePreview(msg)
+	case fileStep, walletStep, passwordStep:
+		return m.updatePrompt(msg)
+	case confirmStep:
+		if msg.String() == "y" || msg.String() == "Y" {
+			m.result = "Signing and sending..."
+			cmd := m.requestor.SignAndSendCmd(m.txn, m.wallet, m.prompt.Value())
+			m.prompt.Reset()
+			m, _ = m.setStep(previewStep)
+			return m, cmd
+		}
+		m.prompt.Reset()
+		return m.setStep(previewStep)
+	case catchupSourceStep:
+		return m.updateCatchupSource(msg)
+	case catchupLabelStep, catchupFileStep:
+		return m.updateCatchupPrompt(msg)
+	case catchupFetchStep:
+		if key.Matches(msg, util.AppKeys.Back) {
+			return m.setStep(catchupSourceStep)
+		}
+	case catchupConfirmStep:
+		return m.updateCatchupConfirm(msg)
+	}
+	return m, nil
+}
+
+func (m Model) updateChoice(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	choices := m.choices()
+	switch {
+	case key.Matches(msg, util.AppKeys.Back):
+		if m.step == accountStep {
+			return m.setStep(menuStep)
+		}
+		return m.setStep(m.step - 1)
+	case msg.String() == "up" || msg.String() == "k":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case msg.String() == "down" || msg.String() == "j":
+		if m.cursor < len(choices)-1 {
+			m.cursor++
+		}
+	case key.Matches(msg, util.AppKeys.Forward):
+		if len(choices) == 0 {
+			return m, nil
+		}
+		switch m.step {
+		case accountStep:
+			m.request = keyregRequest{address: m.accounts[m.cursor].String()}
+			return m.setStep(modeStep)
+		case modeStep:
+			m.request.online = m.cursor == 0
+			if m.request.online {
+				return m.setStep(keyStep)
+			}
+		case keyStep:
+			m.request.key = m.accountKeys()[m.cursor]
+		}
+		m.txn = types.Transaction{}
+		m.result = ""
+		m, _ = m.setStep(previewStep)
+		return m, m.getParamsCmd()
+	}
+	return m, nil
+}
+
+func (m Model) updatePreview(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	ready := m.txn.Type != ""
+	switch {
+	case key.Matches(msg, util.AppKeys.Back):
+		if m.request.online {
+			return m.setStep(keyStep)
+		}
+		return m.setStep(modeStep)
+	case msg.String() == "w" && ready:
+		return m.setStep(fileStep)
+	case msg.String() == "s" && ready:
+		if !m.requestor.CanUseKmd() {
+			m.err = fmt.Errorf("signing requires kmd, use --kmd-url or start kmd in the data directory")
+			m.result = ""
+			return m, nil
+		}
+		return m.setStep(walletStep)
+	case msg.String() == "r":
+		m.txn = types.Transaction{}
+		m.err = nil
+		return m, m.getParamsCmd()
+	}
+	return m, nil
+}
+
+func (m Model) updatePrompt(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, util.AppKeys.Back):
+		m.prompt.Reset()
+		return m.setStep(previewStep)
+	case key.Matches(msg, util.AppKeys.Forward):
+		value := strings.TrimSpace(m.prompt.Value())
+		switch m.step {
+		case fileStep:
+			if value == "" {
+				return m, nil
+			}
+			if err := writeUnsigned(value, m.txn); err != nil {
+				m.err = fmt.Errorf("unable to write transaction: %w", err)
+				return m, nil
+			}
+			m, cmd := m.setStep(previewStep)
+			m.result = fmt.Sprintf("Wrote the unsigned transaction to %s, sign it with 'goal clerk sign'.", value)
+			return m, cmd
+		case walletStep:
+			if value == "" {
+				return m, nil
+			}
+			m.wallet = value
+			return m.setStep(passwordStep)
+		case passwordStep:
+			// the password is kept in the prompt until the transaction is signed.
+			m.step = confirmStep
+			m.prompt.Blur()
+			return m, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	m.prompt, cmd = m.prompt.Update(msg)
+	return m, cmd
+}
+
+// flowView renders the current step of the key registration flow.
+func (m Model) flowView() string {
+	bold := m.style.StatusBoldText
+	var b strings.Builder
+	b.WriteString(bold.Render("Key registration"))
+	b.WriteString("\n\n")
+
+	switch m.step {
+	case accountStep, modeStep, keyStep:
+		headings := map[step]string{
+			accountStep: "Select a watched account:",
+			modeStep:    fmt.Sprintf("Register %s:", m.request.address),
+			keyStep:     fmt.Sprintf("Select a participation key for %s:", m.request.address),
+		}
+		b.WriteString(headings[m.step])
+		b.WriteString("\n")
+		choices := m.choices()
+		for i, c := range choices {
+			if i == m.cursor {
+				b.WriteString(activeStyle.Render("> " + c))
+			} else {
+				b.WriteString("  " + c)
+			}
+			b.WriteString("\n")
+		}
+		switch {
+		case len(choices) > 0:
+			b.WriteString("\nenter: select, esc: back\n")
+		case m.step == accountStep:
+			b.WriteString("No accounts are being watched, add them in the accounts tab.\n")
+		case m.step == keyStep:
+			b.WriteString("No participation keys are installed for this account, generate one in the participation tab.\n")
+		}
+
+	default:
+		fmt.Fprintf(&b, "Key registration to go %s for %s\n\n", m.request.mode(), m.request.address)
+		if m.txn.Type == "" && m.err == nil {
+			b.WriteString("Fetching transaction parameters...\n")
+		} else if m.txn.Type != "" {
+			b.WriteString(keyregPreview(m.txn))
+			for _, w := range keyregWarnings(m.request, m.txn) {
+				b.WriteString(warningStyle.Render("Warning: " + w))
+				b.WriteString("\n")
+			}
+		}
+		b.WriteString("\n")
+
+		switch m.step {
+		case fileStep, walletStep, passwordStep:
+			b.WriteString(m.prompt.View())
+			b.WriteString("\n")
+		case confirmStep:
+			fmt.Fprintf(&b, "Sign with wallet '%s' and send the transaction? Press y to confirm, any other key to cancel.\n", m.wallet)
+		default:
+			b.WriteString("w: write unsigned file, s: sign and send with kmd, r: refresh parameters, esc: back\n")
+		}
+	}
+
+	if m.err != nil {
+		fmt.Fprintf(&b, "Error: %s\n", m.err)
+	}
+	if m.result != "" {
+		b.WriteString(m.result)
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// View is part of the tea.Model interface.
+func (m Model) View() string {
+	if m.step == menuStep {
+		return m.menu.View()
+	}
+	view := m.flowView()
+	if m.step >= catchupSourceStep {
+		view = m.catchupView()
+	}
+	width := max(0, m.width-m.style.Bottom.GetHorizontalFrameSize())
+	content := lipgloss.NewStyle().Width(width).Render(view)
+	height := m.height - m.heightMargin - m.style.Bottom.GetVerticalBorderSize()
+	return m.style.Bottom.Copy().Height(max(0, height)).Render(content)
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -12,12 +12,33 @@ type AppKeyMap struct {
 	Section      key.Binding
 	Forward      key.Binding
 	Back         key.Binding
+	GotoRound    key.Binding
+	BlockHeader  key.Binding
+	Search       key.Binding
+	SortColumn   key.Binding
+	SortOrder    key.Binding
+	TypeFilter   key.Binding
+	SenderFilter key.Binding
+	Expand       key.Binding
+	GroupSummary key.Binding
+	WatchTxn     key.Binding
+	NewPartKey   key.Binding
+	DelPartKey   key.Binding
+	Keyreg       key.Binding
+	AddWatch     key.Binding
+	RemoveWatch  key.Binding
+	LabelWatch   key.Binding
+	Activity     key.Binding
+	ResetConfig  key.Binding
+	SaveConfig   key.Binding
+	ConfigFile   key.Binding
+	ConfigDiff   key.Binding
 	Help         key.Binding
 }
 
 // ShortHelp implements the AppKeyMap interface.
 func (k *AppKeyMap) ShortHelp() []key.Binding {
-	return []key.Binding{k.Section, k.Forward, k.Back, k.Generic, k.Catchup, k.AbortCatchup, k.Shutdown, k.Quit, k.Help}
+	return []key.Binding{k.Section, k.Forward, k.Back, k.Generic, k.GotoRound, k.BlockHeader, k.Search, k.SortColumn, k.SortOrder, k.TypeFilter, k.SenderFilter, k.Expand, k.GroupSummary, k.WatchTxn, k.NewPartKey, k.DelPartKey, k.Keyreg, k.AddWatch, k.RemoveWatch, k.LabelWatch, k.Activity, k.ResetConfig, k.SaveConfig, k.ConfigFile, k.ConfigDiff, k.Catchup, k.AbortCatchup, k.Shutdown, k.Quit, k.Help}
 }
 
 // FullHelp implements the AppKeyMap interface.
@@ -53,4 +74,67 @@ var AppKeys = &AppKeyMap{
 	Back: key.NewBinding(
 		key.WithKeys("esc", "←"),
 		key.WithHelp("esc", "backwards")),
+	GotoRound: key.NewBinding(
+		key.WithKeys("g"),
+		key.WithHelp("g", "go to round")),
+	BlockHeader: key.NewBinding(
+		key.WithKeys("h"),
+		key.WithHelp("h", "block header")),
+	Search: key.NewBinding(
+		key.WithKeys("/"),
+		key.WithHelp("/", "search")),
+	SortColumn: key.NewBinding(
+		key.WithKeys("o"),
+		key.WithHelp("o", "sort column")),
+	SortOrder: key.NewBinding(
+		key.WithKeys("O"),
+		key.WithHelp("O", "reverse sort")),
+	TypeFilter: key.NewBinding(
+		key.WithKeys("t"),
+		key.WithHelp("t", "filter type")),
+	SenderFilter: key.NewBinding(
+		key.WithKeys("@"),
+		key.WithHelp("@", "filter sender")),
+	Expand: key.NewBinding(
+		key.WithKeys(" "),
+		key.WithHelp("space", "inner txns")),
+	GroupSummary: key.NewBinding(
+		key.WithKeys("G"),
+		key.WithHelp("G", "group summary")),
+	WatchTxn: key.NewBinding(
+		key.WithKeys("w"),
+		key.WithHelp("w", "watch txid")),
+	NewPartKey: key.NewBinding(
+		key.WithKeys("n"),
+		key.WithHelp("n", "new part key")),
+	DelPartKey: key.NewBinding(
+		key.WithKeys("x"),
+		key.WithHelp("x", "delete part key")),
+	Keyreg: key.NewBinding(
+		key.WithKeys("k"),
+		key.WithHelp("k", "keyreg txn")),
+	AddWatch: key.NewBinding(
+		key.WithKeys("+"),
+		key.WithHelp("+", "watch address")),
+	RemoveWatch: key.NewBinding(
+		key.WithKeys("-"),
+		key.WithHelp("-", "unwatch address")),
+	LabelWatch: key.NewBinding(
+		key.WithKeys("l"),
+		key.WithHelp("l", "label address")),
+	Activity: key.NewBinding(
+		key.WithKeys("v"),
+		key.WithHelp("v", "activity")),
+	ResetConfig: key.NewBinding(
+		key.WithKeys("d"),
+		key.WithHelp("d", "reset to default")),
+	SaveConfig: key.NewBinding(
+		key.WithKeys("w"),
+		key.WithHelp("w", "review and save")),
+	ConfigFile: key.NewBinding(
+		key.WithKeys("v"),
+		key.WithHelp("v", "view config.json")),
+	ConfigDiff: key.NewBinding(
+		key.WithKeys("c"),
+		key.WithHelp("c", "diff defaults")),
 }
@@ -0,0 +1,14 @@
+package util
+
+// InputCapturer is implemented by bubbles which can temporarily take over the
+// keyboard, for example while a text prompt is focused. Global key bindings
+// should not be processed while CapturingInput returns true.
+type InputCapturer interface {
+	CapturingInput() bool
+}
+
+// KeyUpdater is implemented by bubbles with their own key bindings. The
+// bindings should only be enabled while the bubble is active.
+type KeyUpdater interface {
+	UpdateKeys(active bool)
+}
@@ -0,0 +1,48 @@
+package util
+
+import (
+	"strings"
+)
+
+// sparkBlocks are the characters used by Sparkline, from low to high.
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// Sparkline renders the last width values as a single line scaled between the
+// smallest and largest of those values. Missing values are padded on the left.
+func Sparkline(values []float64, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	if len(values) > width {
+		values = values[len(values)-width:]
+	}
+
+	var b strings.Builder
+	b.WriteString(strings.Repeat(" ", width-len(values)))
+	if len(values) == 0 {
+		return b.String()
+	}
+
+	low, high := values[0], values[0]
+	for _, v := range values {
+		if v < low {
+			low = v
+		}
+		if v > high {
+			high = v
+		}
+	}
+
+	top := len(sparkBlocks) - 1
+	for _, v := range values {
+		level := 0
+		if high > low {
+			level = int((v - low) / (high - low) * float64(top))
+		}
+		if level < 0 || level > top {
+			level = 0
+		}
+		b.WriteRune(sparkBlocks[level])
+	}
+	return b.String()
+}
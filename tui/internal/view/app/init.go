@@ -10,9 +10,16 @@ func (m Model) Init() tea.Cmd {
 		m.Status.Init(),
 		m.Accounts.Init(),
 		m.BlockExplorer.Init(),
+		m.Mempool.Init(),
+		m.Metrics.Init(),
+		m.Rounds.Init(),
+		m.Upgrade.Init(),
+		m.Peers.Init(),
+		m.Participation.Init(),
 		m.Configs.Init(),
 		m.Tabs.Init(),
 		m.About.Init(),
 		m.Utilities.Init(),
+		m.Alerts.Init(),
 	)
 }
@@ -1,17 +1,26 @@
 package app
 
 import (
-	"github.com/algorand/go-algorand-sdk/v2/types"
+	"io"
+
 	"github.com/charmbracelet/bubbles/help"
 	tea "github.com/charmbracelet/bubbletea"
 
 	"github.com/winder/algorand-navigator/messages"
 	"github.com/winder/algorand-navigator/tui/internal/bubbles/about"
 	"github.com/winder/algorand-navigator/tui/internal/bubbles/accounts"
+	"github.com/winder/algorand-navigator/tui/internal/bubbles/alerts"
 	"github.com/winder/algorand-navigator/tui/internal/bubbles/configs"
 	"github.com/winder/algorand-navigator/tui/internal/bubbles/explorer"
+	"github.com/winder/algorand-navigator/tui/internal/bubbles/mempool"
+	"github.com/winder/algorand-navigator/tui/internal/bubbles/metrics"
+	"github.com/winder/algorand-navigator/tui/internal/bubbles/participation"
+	"github.com/winder/algorand-navigator/tui/internal/bubbles/peers"
+	"github.com/winder/algorand-navigator/tui/internal/bubbles/rounds"
 	"github.com/winder/algorand-navigator/tui/internal/bubbles/status"
 	"github.com/winder/algorand-navigator/tui/internal/bubbles/tabs"
+	"github.com/winder/algorand-navigator/tui/internal/bubbles/upgrade"
+	"github.com/winder/algorand-navigator/tui/internal/bubbles/utilities"
 	"github.com/winder/algorand-navigator/tui/internal/style"
 	"github.com/winder/algorand-navigator/tui/internal/util"
 )
@@ -20,10 +29,17 @@ type activeComponent int
 
 const (
 	explorerTab activeComponent = iota
+	mempoolTab
+	metricsTab
+	roundsTab
+	upgradeTab
+	peersTab
+	participationTab
 	utilitiesTab
 	accountTab
 	configTab
 	helpTab
+	numTabs
 )
 
 // Model represents the top level of the TUI.
@@ -32,12 +48,20 @@ type Model struct {
 	Accounts      tea.Model
 	Tabs          tabs.Model
 	BlockExplorer tea.Model
+	Mempool       tea.Model
+	Metrics       tea.Model
+	Rounds        tea.Model
+	Upgrade       tea.Model
+	Peers         tea.Model
+	Participation tea.Model
 	Configs       tea.Model
 	Utilities     tea.Model
 	About         tea.Model
+	Alerts        tea.Model
 	help          help.Model
 
-	network messages.NetworkMsg
+	// catchpoint is the fast catchup in progress, if any.
+	catchpoint string
 
 	styles *style.Styles
 
@@ -48,12 +72,14 @@ type Model struct {
 	lastResize tea.WindowSizeMsg
 }
 
-// New initializes the TUI.
-func New(initialWidth, initialHeight int, requestor *messages.Requestor, addresses []types.Address) Model {
+// New initializes the TUI, invalid watch list entries are reported on the
+// accounts tab. output is the terminal of the program.
+func New(initialWidth, initialHeight int, requestor *messages.Requestor, watchList []string, output io.Writer) Model {
+	addresses, watchErr := accounts.ParseWatchList(watchList)
 	util.AppKeys.Shutdown.SetEnabled(requestor.CanShutdown())
 
 	styles := style.DefaultStyles()
-	tab := tabs.New([]string{"EXPLORER", "UTILITIES", "ACCOUNTS", "CONFIGURATION", "HELP"})
+	tab := tabs.New([]string{"EXPLORER", "MEMPOOL", "METRICS", "ROUNDS", "UPGRADE", "PEERS", "PARTICIPATION", "UTILITIES", "ACCOUNTS", "CONFIGURATION", "HELP"})
 	// The tab content is the only flexible element.
 	// This means the height must grow or shrink to fill the available
 	// window height. It has access to the absolute height but needs to
@@ -66,11 +92,75 @@ func New(initialWidth, initialHeight int, requestor *messages.Requestor, address
 		Status:        status.New(styles, requestor),
 		Tabs:          tab,
 		BlockExplorer: explorer.New(styles, requestor, initialWidth, 0, initialHeight, tabContentMargin),
-		Configs:       configs.New(requestor, tabContentMargin),
-		Accounts:      accounts.New(styles, requestor, initialHeight, tabContentMargin, addresses),
+		Mempool:       mempool.New(styles, requestor, tabContentMargin),
+		Metrics:       metrics.New(styles, requestor, tabContentMargin),
+		Rounds:        rounds.New(styles, tabContentMargin),
+		Upgrade:       upgrade.New(styles, requestor, tabContentMargin),
+		Peers:         peers.New(styles, requestor, tabContentMargin),
+		Participation: participation.New(styles, requestor, tabContentMargin),
+		Configs:       configs.New(styles, requestor, tabContentMargin),
+		Accounts:      accounts.New(styles, requestor, initialHeight, tabContentMargin, addresses, watchErr),
 		About:         about.New(tabContentMargin, about.GetHelpContent()),
-		Utilities:     about.New(tabContentMargin, about.GetUtilsContent()),
+		Utilities:     utilities.New(styles, requestor, tabContentMargin, addresses),
+		Alerts:        alerts.New(output),
 		help:          help.New(),
 		requestor:     requestor,
 	}
 }
+
+// tab returns the model of a tab.
+func (m Model) tab(c activeComponent) tea.Model {
+	switch c {
+	case explorerTab:
+		return m.BlockExplorer
+	case mempoolTab:
+		return m.Mempool
+	case metricsTab:
+		return m.Metrics
+	case roundsTab:
+		return m.Rounds
+	case upgradeTab:
+		return m.Upgrade
+	case peersTab:
+		return m.Peers
+	case participationTab:
+		return m.Participation
+	case utilitiesTab:
+		return m.Utilities
+	case accountTab:
+		return m.Accounts
+	case configTab:
+		return m.Configs
+	case helpTab:
+		return m.About
+	}
+	return nil
+}
+
+// setTab replaces the model of a tab.
+func (m *Model) setTab(c activeComponent, model tea.Model) {
+	switch c {
+	case explorerTab:
+		m.BlockExplorer = model
+	case mempoolTab:
+		m.Mempool = model
+	case metricsTab:
+		m.Metrics = model
+	case roundsTab:
+		m.Rounds = model
+	case upgradeTab:
+		m.Upgrade = model
+	case peersTab:
+		m.Peers = model
+	case participationTab:
+		m.Participation = model
+	case utilitiesTab:
+		m.Utilities = model
+	case accountTab:
+		m.Accounts = model
+	case configTab:
+		m.Configs = model
+	case helpTab:
+		m.About = model
+	}
+}
@@ -1,62 +1,92 @@
 package app
 
 import (
-	"strings"
-
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 
 	"github.com/winder/algorand-navigator/messages"
+	"github.com/winder/algorand-navigator/tui/internal/bubbles/explorer"
 	"github.com/winder/algorand-navigator/tui/internal/util"
 )
 
-func networkFromID(genesisID string) string {
-	return strings.Split(genesisID, "-")[0]
+// capturingInput reports whether the active tab has taken over the keyboard.
+func (m Model) capturingInput() bool {
+	capturer, ok := m.tab(m.active).(util.InputCapturer)
+	return ok && capturer.CapturingInput()
+}
+
+// updateKeys enables the key bindings of the active tab.
+func (m Model) updateKeys() {
+	for c := activeComponent(0); c < numTabs; c++ {
+		if updater, ok := m.tab(c).(util.KeyUpdater); ok {
+			updater.UpdateKeys(c == m.active)
+		}
+	}
+}
+
+// setActive selects a tab.
+func (m *Model) setActive(c activeComponent) {
+	m.active = c
+	m.Tabs.SetActiveIndex(int(m.active))
 }
 
 // Update is part of the tea.Model interface.
 func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	m, cmd := m.update(msg)
+	m.updateKeys()
+	return m, cmd
+}
+
+// updateActive sends a message to the active tab only.
+func (m Model) updateActive(msg tea.Msg) (Model, tea.Cmd) {
+	tab, cmd := m.tab(m.active).Update(msg)
+	m.setTab(m.active, tab)
+	return m, cmd
+}
+
+func (m Model) update(msg tea.Msg) (Model, tea.Cmd) {
 	var (
 		cmd  tea.Cmd
 		cmds []tea.Cmd
 	)
 
 	switch msg := msg.(type) {
-	case messages.NetworkMsg:
-		m.network = msg
+	case messages.StatusMsg:
+		if msg.Error == nil {
+			m.catchpoint = msg.Status.Catchpoint
+		}
+
+	case explorer.GotoRoundMsg, explorer.ShowTxnMsg:
+		m.setActive(explorerTab)
 
 	case tea.KeyMsg:
+		if m.capturingInput() {
+			return m.updateActive(msg)
+		}
 		switch {
 		case key.Matches(msg, util.AppKeys.Quit):
 			return m, tea.Quit
 		case key.Matches(msg, util.AppKeys.Catchup):
-			return m, m.requestor.StartFastCatchup(networkFromID(m.network.GenesisID))
+			// the catchpoint is picked in the utilities tab.
+			m.setActive(utilitiesTab)
+			return m.updateActive(msg)
 		case key.Matches(msg, util.AppKeys.AbortCatchup):
-			return m, m.requestor.StopFastCatchup(networkFromID(m.network.GenesisID))
+			return m, m.requestor.AbortFastCatchupCmd(m.catchpoint)
 		case key.Matches(msg, util.AppKeys.Shutdown):
 			// trigger shutdown from a different level.
 			return m, func() tea.Msg {
 				return messages.MakeStopNodeMsg(m.requestor)
 			}
 		case key.Matches(msg, util.AppKeys.Section):
-			m.active++
-			m.active %= 5
-			m.Tabs.SetActiveIndex(int(m.active))
+			m.setActive((m.active + 1) % numTabs)
 			return m, nil
 		}
-		switch m.active {
-		case explorerTab:
-			var explorerCommand tea.Cmd
-			m.BlockExplorer, explorerCommand = m.BlockExplorer.Update(msg)
-			return m, explorerCommand
-		case accountTab:
-		case configTab:
-		case helpTab:
-		case utilitiesTab:
-		}
+		// keys only apply to the active tab.
+		return m.updateActive(msg)
 
 	case tea.WindowSizeMsg:
 		m.lastResize = msg
+		m.help.Width = msg.Width
 	}
 
 	m.Status, cmd = m.Status.Update(msg)
@@ -68,6 +98,24 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 	m.BlockExplorer, cmd = m.BlockExplorer.Update(msg)
 	cmds = append(cmds, cmd)
 
+	m.Mempool, cmd = m.Mempool.Update(msg)
+	cmds = append(cmds, cmd)
+
+	m.Metrics, cmd = m.Metrics.Update(msg)
+	cmds = append(cmds, cmd)
+
+	m.Rounds, cmd = m.Rounds.Update(msg)
+	cmds = append(cmds, cmd)
+
+	m.Upgrade, cmd = m.Upgrade.Update(msg)
+	cmds = append(cmds, cmd)
+
+	m.Peers, cmd = m.Peers.Update(msg)
+	cmds = append(cmds, cmd)
+
+	m.Participation, cmd = m.Participation.Update(msg)
+	cmds = append(cmds, cmd)
+
 	m.Configs, cmd = m.Configs.Update(msg)
 	cmds = append(cmds, cmd)
 
@@ -80,5 +128,8 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 	m.Utilities, cmd = m.Utilities.Update(msg)
 	cmds = append(cmds, cmd)
 
+	m.Alerts, cmd = m.Alerts.Update(msg)
+	cmds = append(cmds, cmd)
+
 	return m, tea.Batch(cmds...)
 }
@@ -7,19 +7,9 @@ import (
 	"github.com/winder/algorand-navigator/tui/internal/util"
 )
 
-// TODO: this function could implement a type and be passed to the tab view.
 func (m Model) tabView() string {
-	switch activeComponent(m.Tabs.GetActiveIndex()) {
-	case explorerTab:
-		return m.BlockExplorer.View()
-	case accountTab:
-		return m.Accounts.View()
-	case configTab:
-		return m.Configs.View()
-	case helpTab:
-		return m.About.View()
-	case utilitiesTab:
-		return m.Utilities.View()
+	if tab := m.tab(activeComponent(m.Tabs.GetActiveIndex())); tab != nil {
+		return tab.View()
 	}
 
 	return "unknown tab"
@@ -46,6 +36,12 @@ func art() string {
 
 // View is part of the tea.Model interface.
 func (m Model) View() string {
+	// Alerts are displayed in place of the help line.
+	bottom := m.Alerts.View()
+	if bottom == "" {
+		bottom = m.help.View(util.AppKeys)
+	}
+
 	// Compose the different views by joining them together in the right orientation.
 	return lipgloss.JoinVertical(0,
 		lipgloss.JoinHorizontal(0,
@@ -53,5 +49,5 @@ func (m Model) View() string {
 			art()),
 		m.Tabs.View(),
 		m.tabView(),
-		m.help.View(util.AppKeys))
+		bottom)
 }
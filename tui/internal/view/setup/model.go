@@ -2,6 +2,7 @@ package setup
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"time"
 
@@ -30,6 +31,8 @@ type Model struct {
 	configDir string
 
 	args args.Arguments
+	// output is the terminal the program writes to.
+	output io.Writer
 
 	installer installer.Model
 	app       app.Model
@@ -40,11 +43,14 @@ type Model struct {
 	sizeMsg tea.WindowSizeMsg
 }
 
-func New(args args.Arguments) (m Model) {
+func New(args args.Arguments, output io.Writer) (m Model) {
+	m.args = args
+	m.output = output
 	requestor, err := getRequestor(args.AlgodDataDir, args.AlgodBinDir, args.AlgodURL, args.AlgodToken, args.AlgodAdminToken)
 	if err == nil {
-		addresses := getAddressesOrExit(args.AddressWatchList)
-		m.app = app.New(util.InitialWidth, util.InitialHeight, requestor, addresses)
+		requestor.SetKmd(args.KmdURL, args.KmdToken)
+		requestor.SetCatchpointSource(args.CatchpointURL)
+		m.app = app.New(util.InitialWidth, util.InitialHeight, requestor, args.AddressWatchList, output)
 		m.state = appState
 
 	} else {
@@ -100,10 +106,15 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case installer.DataDirReady:
 		requestor, err := getRequestor(msg.DataDir, msg.BinDir, "", "", "")
 		if err == nil {
-			addresses := getAddressesOrExit(m.args.AddressWatchList)
-			m.app = app.New(m.sizeMsg.Width, m.sizeMsg.Height, requestor, addresses)
+			requestor.SetKmd(m.args.KmdURL, m.args.KmdToken)
+			requestor.SetCatchpointSource(m.args.CatchpointURL)
+			m.app = app.New(m.sizeMsg.Width, m.sizeMsg.Height, requestor, m.args.AddressWatchList, m.output)
 			m.state = appState
-			return m, m.app.Init()
+			// the config dir was found before the app existed, send it again.
+			configDir := m.configDir
+			return m, tea.Batch(m.app.Init(), func() tea.Msg {
+				return util.NavigatorUIConfigDir{Dir: configDir}
+			})
 		}
 	case nodeShutdownComplete:
 		// TODO: go back to the installer?
@@ -6,8 +6,6 @@ import (
 	"path/filepath"
 	"strings"
 
-	"github.com/algorand/go-algorand-sdk/v2/types"
-
 	"github.com/winder/algorand-navigator/messages"
 )
 
@@ -66,21 +64,3 @@ func getRequestorOrExit(algodDataDir, algodBinDir, url, token, adminToken string
 	}
 	return requestor
 }
-
-func getAddressesOrExit(addrs []string) (result []types.Address) {
-	failed := false
-	for _, addr := range addrs {
-		converted, err := types.DecodeAddress(addr)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Failed to decode address '%s': %s\n", addr, err.Error())
-			failed = true
-		}
-		result = append(result, converted)
-	}
-
-	if failed {
-		os.Exit(1)
-	}
-
-	return result
-}
@@ -21,19 +21,18 @@ import (
 	"github.com/winder/algorand-navigator/tui/internal/view/setup"
 )
 
-func getTeaHandler(model tea.Model) bm.Handler {
-	return func(_ ssh.Session) (tea.Model, []tea.ProgramOption) {
-		return model, []tea.ProgramOption{tea.WithAltScreen(), tea.WithMouseCellMotion()}
+func getTeaHandler(args args.Arguments) bm.Handler {
+	return func(s ssh.Session) (tea.Model, []tea.ProgramOption) {
+		// the session is the output, the bell rings in the client terminal.
+		return setup.New(args, s), []tea.ProgramOption{tea.WithAltScreen(), tea.WithMouseCellMotion()}
 	}
 }
 
 // Start ...
 func Start(args args.Arguments) {
-	model := setup.New(args)
-
 	// Run directly
 	if args.TuiPort == 0 {
-		p := tea.NewProgram(model, tea.WithAltScreen(), tea.WithMouseCellMotion())
+		p := tea.NewProgram(setup.New(args, os.Stdout), tea.WithAltScreen(), tea.WithMouseCellMotion())
 		if _, err := p.Run(); err != nil {
 			fmt.Printf("Error in UI: %v", err)
 			os.Exit(1)
@@ -53,7 +52,7 @@ func Start(args args.Arguments) {
 		wish.WithAddress(fmt.Sprintf("%s:%d", util.Host, args.TuiPort)),
 		wish.WithHostKeyPath(path.Join(dirname, ".ssh/term_info_ed25519")),
 		wish.WithMiddleware(
-			bm.Middleware(getTeaHandler(model)),
+			bm.Middleware(getTeaHandler(args)),
 			lm.Middleware(),
 		),
 	)